@@ -3,6 +3,10 @@ package collectors
 import (
 	"context"
 	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -165,3 +169,179 @@ func (c *GithubCollector) collect(ctx context.Context, collector string, ch chan
 
 	c.wg.Done()
 }
+
+// listRepositories returns the names of repositories to scrape for the given
+// organization. If gh-repositories is configured, it is used as is, otherwise
+// all organization repositories are listed
+func listRepositories(ctx context.Context, client *github.Client, org string) ([]string, error) {
+	repos := viper.GetStringSlice("gh-repositories")
+	if len(repos) > 0 {
+		return repos, nil
+	}
+
+	results, err := listOrganizationRepositories(ctx, client, org)
+	if err != nil {
+		return nil, err
+	}
+	for _, repo := range results {
+		repos = append(repos, repo.GetName())
+	}
+
+	return repos, nil
+}
+
+// getRepositories returns repositories to scrape for the given organization.
+// If gh-repositories is configured, each of them is requested separately,
+// otherwise all organization repositories are listed
+func getRepositories(ctx context.Context, client *github.Client, org string) ([]*github.Repository, error) {
+	names := viper.GetStringSlice("gh-repositories")
+	if len(names) <= 0 {
+		return listOrganizationRepositories(ctx, client, org)
+	}
+
+	repos := make([]*github.Repository, 0, len(names))
+	for _, name := range names {
+		repo, _, err := client.Repositories.Get(ctx, org, name)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, repo)
+	}
+
+	return repos, nil
+}
+
+func listOrganizationRepositories(ctx context.Context, client *github.Client, org string) ([]*github.Repository, error) {
+	repos := make([]*github.Repository, 0)
+	opts := &github.RepositoryListByOrgOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	for {
+		results, resp, err := client.Repositories.ListByOrg(ctx, org, opts)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, results...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return repos, nil
+}
+
+// addListOptions adds pagination parameters to the URL of endpoints which
+// are not implemented by the Github client yet
+func addListOptions(s string, opts *github.ListOptions) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return s, err
+	}
+	q := u.Query()
+	if opts.PerPage != 0 {
+		q.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page != 0 {
+		q.Set("page", strconv.Itoa(opts.Page))
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// addListCursorOptions adds cursor pagination parameters to the URL of
+// endpoints which are not implemented by the Github client yet
+func addListCursorOptions(s string, opts *github.ListCursorOptions) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return s, err
+	}
+	q := u.Query()
+	if opts.PerPage != 0 {
+		q.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.After != "" {
+		q.Set("after", opts.After)
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// isFeatureUnavailable reports whether the error is caused by a Github feature
+// being disabled or inaccessible for the repository (404 or 403)
+func isFeatureUnavailable(err error) bool {
+	e, ok := err.(*github.ErrorResponse)
+	if !ok || e.Response == nil {
+		return false
+	}
+	return e.Response.StatusCode == http.StatusNotFound || e.Response.StatusCode == http.StatusForbidden
+}
+
+// isNotFound reports whether the error is caused by the resource not existing
+// (404)
+func isNotFound(err error) bool {
+	e, ok := err.(*github.ErrorResponse)
+	if !ok || e.Response == nil {
+		return false
+	}
+	return e.Response.StatusCode == http.StatusNotFound
+}
+
+// isPlanUnsupported reports whether the error is caused by the Github feature
+// not being available on the plan of the repository owner (403), as opposed to
+// missing permissions
+func isPlanUnsupported(err error) bool {
+	e, ok := err.(*github.ErrorResponse)
+	if !ok || e.Response == nil {
+		return false
+	}
+	return e.Response.StatusCode == http.StatusForbidden && strings.Contains(e.Message, "Upgrade to GitHub Pro")
+}
+
+// isRepositoryEmpty reports whether the error is caused by the repository
+// having no commits yet (409)
+func isRepositoryEmpty(err error) bool {
+	e, ok := err.(*github.ErrorResponse)
+	if !ok || e.Response == nil {
+		return false
+	}
+	return e.Response.StatusCode == http.StatusConflict
+}
+
+// boolToFloat64 converts the boolean to a Prometheus metric value
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// sendError sends the error to the channel without blocking. Only the first
+// error is kept, the following ones are dropped
+func sendError(errCh chan<- error, err error) {
+	select {
+	case errCh <- err:
+	default:
+	}
+}
+
+// newConstHistogram creates a constant histogram of the observed values
+// distributed over the given buckets
+func newConstHistogram(desc *metrics.Desc, buckets []float64, values []float64, labels ...string) metrics.Metric {
+	sum := 0.0
+	counts := make(map[float64]uint64, len(buckets))
+	for _, bucket := range buckets {
+		counts[bucket] = 0
+	}
+	for _, value := range values {
+		sum += value
+		for _, bucket := range buckets {
+			if value <= bucket {
+				counts[bucket]++
+			}
+		}
+	}
+	return metrics.MustNewConstHistogram(desc, uint64(len(values)), sum, counts, labels...)
+}
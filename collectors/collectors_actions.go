@@ -2,10 +2,16 @@ package collectors
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/go-github/v42/github"
+	"github.com/konradasb/github_exporter/transport"
+	"github.com/pkg/errors"
 	metrics "github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -13,41 +19,130 @@ import (
 
 var (
 	ActionsFlagset = pflag.NewFlagSet("actions", pflag.ExitOnError)
+
+	// Buckets of workflow runs duration, from 30 seconds up to 2 hours
+	actionsDurationBuckets = []float64{30, 60, 120, 300, 600, 900, 1800, 3600, 7200}
+	// Buckets of workflow runs queue time, from 5 seconds up to 30 minutes
+	actionsQueueTimeBuckets = []float64{5, 10, 30, 60, 120, 300, 600, 1800}
 )
 
+// Github Actions cache size limit of a single repository
+const actionsCacheLimitBytes = 10 * 1024 * 1024 * 1024
+
+// Maximum age of cached workflow runs and jobs used to collect jobs demand
+const actionsJobsDemandMaxAge = 15 * time.Second
+
+// actionsCacheUsage represents Github Actions cache usage of an organization
+type actionsCacheUsage struct {
+	TotalActiveCachesCount       int64 `json:"total_active_caches_count"`
+	TotalActiveCachesSizeInBytes int64 `json:"total_active_caches_size_in_bytes"`
+}
+
+// actionsRepositoryCacheUsage represents Github Actions cache usage of a repository
+type actionsRepositoryCacheUsage struct {
+	FullName                string `json:"full_name"`
+	ActiveCachesCount       int64  `json:"active_caches_count"`
+	ActiveCachesSizeInBytes int64  `json:"active_caches_size_in_bytes"`
+}
+
+// actionsRepositoryCacheUsages represents a list of Github Actions cache usages
+// of an organization repositories
+type actionsRepositoryCacheUsages struct {
+	TotalCount            int                            `json:"total_count"`
+	RepositoryCacheUsages []*actionsRepositoryCacheUsage `json:"repository_cache_usages"`
+}
+
+type workflowRunsKey struct {
+	workflow   string
+	branch     string
+	event      string
+	conclusion string
+}
+
+type jobsDemandKey struct {
+	labels string
+	status string
+}
+
 type actionsCollector struct {
 	runnersStatus      *metrics.Desc
+	runnersLabelsInfo  *metrics.Desc
+	runnerGroupsCount  *metrics.Desc
 	runnersIdleCount   *metrics.Desc
 	runnersBusyCount   *metrics.Desc
 	workflowStatus     *metrics.Desc
 	workflowRunsStatus *metrics.Desc
 
-	client *github.Client
+	workflowRunsDuration  *metrics.Desc
+	workflowRunsQueueTime *metrics.Desc
+
+	jobsStatusCount  *metrics.Desc
+	jobsRunnersCount *metrics.Desc
+
+	cacheActiveCount *metrics.Desc
+	cacheActiveSize  *metrics.Desc
+	cacheUsageRatio  *metrics.Desc
+
+	artifactsCount *metrics.Desc
+	artifactsSize  *metrics.Desc
+
+	client           *github.Client
+	enterpriseClient *github.Client
 
 	wg *sync.WaitGroup
 }
 
 func newActionsCollector(client *github.Client) (Collector, error) {
+	// Github App installations can't access enterprise endpoints, enterprises
+	// are scraped with a personal access token of an enterprise owner instead
+	var enterpriseClient *github.Client
+	if len(viper.GetStringSlice("gh-enterprises")) > 0 {
+		token := viper.GetString("gh-enterprise-token")
+		if token == "" {
+			return nil, errors.New("gh-enterprise-token is required to scrape enterprises")
+		}
+		enterpriseClient = github.NewClient(
+			&http.Client{
+				Transport: transport.NewTransport(&github.BasicAuthTransport{Username: "token", Password: token}).
+					WithRatelimit().WithThrottle(nil).WithCache(nil),
+			},
+		)
+	}
+
 	runnersStatus := metrics.NewDesc(
 		metrics.BuildFQName(
 			defaultNamespace, "actions", "runners_status",
 		),
 		"Status of Github Actions runners",
-		[]string{"name", "status", "busy", "os", "org", "repo"}, nil,
+		[]string{"name", "status", "busy", "os", "org", "repo", "enterprise"}, nil,
+	)
+	runnersLabelsInfo := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "runner_labels_info",
+		),
+		"Labels of Github Actions runners",
+		[]string{"name", "label", "type", "org", "repo", "enterprise"}, nil,
+	)
+	runnerGroupsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "runner_groups_runners_count",
+		),
+		"Total Github Action runners per runner group and status",
+		[]string{"org", "group", "status"}, nil,
 	)
 	runnersIdleCount := metrics.NewDesc(
 		metrics.BuildFQName(
 			defaultNamespace, "actions", "runners_idle_count",
 		),
 		"Total idle Github Action runners",
-		[]string{"org", "repo"}, nil,
+		[]string{"org", "repo", "enterprise"}, nil,
 	)
 	runnersBusyCount := metrics.NewDesc(
 		metrics.BuildFQName(
 			defaultNamespace, "actions", "runners_busy_count",
 		),
 		"Total busy Github Action runners",
-		[]string{"org", "repo"}, nil,
+		[]string{"org", "repo", "enterprise"}, nil,
 	)
 	workflowStatus := metrics.NewDesc(
 		metrics.BuildFQName(
@@ -63,15 +158,93 @@ func newActionsCollector(client *github.Client) (Collector, error) {
 		"Status of Github Action workflow runs",
 		[]string{"org", "repo", "status"}, nil,
 	)
+	workflowRunsDuration := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "workflows_runs_duration_seconds",
+		),
+		"Duration of completed Github Action workflow runs",
+		[]string{"org", "repo", "workflow", "branch", "event", "conclusion"}, nil,
+	)
+	workflowRunsQueueTime := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "workflows_runs_queue_time_seconds",
+		),
+		"Queue time of completed Github Action workflow runs",
+		[]string{"org", "repo", "workflow", "branch", "event", "conclusion"}, nil,
+	)
+
+	jobsStatusCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "jobs_status_count",
+		),
+		"Total queued and in progress Github Action workflow jobs per requested runner labels",
+		[]string{"org", "labels", "status"}, nil,
+	)
+	jobsRunnersCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "jobs_demand_runners_count",
+		),
+		"Total online Github Action runners matching requested runner labels of queued and in progress workflow jobs",
+		[]string{"org", "labels", "busy"}, nil,
+	)
+
+	cacheActiveCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "cache_active_count",
+		),
+		"Total active Github Actions caches",
+		[]string{"org", "repo"}, nil,
+	)
+	cacheActiveSize := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "cache_active_size_bytes",
+		),
+		"Total size of active Github Actions caches",
+		[]string{"org", "repo"}, nil,
+	)
+	cacheUsageRatio := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "cache_usage_ratio",
+		),
+		"Ratio of active Github Actions caches size to the repository cache limit",
+		[]string{"org", "repo"}, nil,
+	)
+
+	artifactsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "artifacts_count",
+		),
+		"Total Github Actions artifacts",
+		[]string{"org", "repo", "expired"}, nil,
+	)
+	artifactsSize := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "artifacts_size_bytes",
+		),
+		"Total size of Github Actions artifacts",
+		[]string{"org", "repo", "expired"}, nil,
+	)
 
 	c := &actionsCollector{
-		workflowRunsStatus: workflowRunsStatus,
-		workflowStatus:     workflowStatus,
-		runnersBusyCount:   runnersBusyCount,
-		runnersIdleCount:   runnersIdleCount,
-		runnersStatus:      runnersStatus,
-		client:             client,
-		wg:                 &sync.WaitGroup{},
+		artifactsCount:        artifactsCount,
+		artifactsSize:         artifactsSize,
+		cacheActiveCount:      cacheActiveCount,
+		cacheActiveSize:       cacheActiveSize,
+		cacheUsageRatio:       cacheUsageRatio,
+		jobsRunnersCount:      jobsRunnersCount,
+		jobsStatusCount:       jobsStatusCount,
+		workflowRunsQueueTime: workflowRunsQueueTime,
+		workflowRunsDuration:  workflowRunsDuration,
+		workflowRunsStatus:    workflowRunsStatus,
+		workflowStatus:        workflowStatus,
+		runnersBusyCount:      runnersBusyCount,
+		runnersIdleCount:      runnersIdleCount,
+		runnersStatus:         runnersStatus,
+		runnersLabelsInfo:     runnersLabelsInfo,
+		runnerGroupsCount:     runnerGroupsCount,
+		client:                client,
+		enterpriseClient:      enterpriseClient,
+		wg:                    &sync.WaitGroup{},
 	}
 
 	return c, nil
@@ -79,45 +252,46 @@ func newActionsCollector(client *github.Client) (Collector, error) {
 
 func (c *actionsCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
 	errCh := make(chan error, 1)
+	for _, enterprise := range viper.GetStringSlice("gh-enterprises") {
+		c.wg.Add(1)
+		go func(enterprise string) {
+			c.scrapeEnterpriseRunners(ctx, ch, errCh, enterprise)
+			c.wg.Done()
+		}(enterprise)
+	}
+
 	for _, org := range viper.GetStringSlice("gh-organizations") {
-		repos := viper.GetStringSlice("gh-repositories")
-		if len(repos) <= 0 {
-			opts := &github.RepositoryListByOrgOptions{
-				ListOptions: github.ListOptions{
-					PerPage: 100,
-				},
-			}
-			for {
-				results, resp, err := c.client.Repositories.ListByOrg(ctx, org, opts)
-				if err != nil {
-					errCh <- err
-					break
-				}
-				for _, repo := range results {
-					repos = append(repos, repo.GetName())
-				}
-				if resp.NextPage == 0 {
-					break
-				}
-				opts.Page = resp.NextPage
-			}
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
 		}
 
 		c.wg.Add(1)
 		go func(org string) {
 			c.scrapeOrganizationRunners(ctx, ch, errCh, org)
+			c.scrapeOrganizationRunnerGroups(ctx, ch, errCh, org)
 			c.wg.Done()
 		}(org)
 
+		c.wg.Add(1)
+		go func(org string, repos []string) {
+			c.scrapeOrganizationJobsDemand(ctx, ch, errCh, org, repos)
+			c.scrapeOrganizationCacheUsage(ctx, ch, errCh, org, repos)
+			c.wg.Done()
+		}(org, repos)
+
 		for _, repo := range repos {
 			c.wg.Add(1)
-			go func(repo string) {
+			go func(org string, repo string) {
+				c.scrapeRepositoryRunners(ctx, ch, errCh, org, repo)
 				c.scrapeWorkflows(ctx, ch, errCh, org, repo)
 				c.scrapeRepositoryWorkflowRunsByStatus(ctx, ch, errCh, org, repo, "queued")
 				c.scrapeRepositoryWorkflowRunsByStatus(ctx, ch, errCh, org, repo, "in_progress")
 				c.scrapeRepositoryWorkflowRunsByStatus(ctx, ch, errCh, org, repo, "completed")
+				c.scrapeRepositoryWorkflowRunsDurations(ctx, ch, errCh, org, repo)
+				c.scrapeRepositoryArtifacts(ctx, ch, errCh, org, repo)
 				c.wg.Done()
-			}(repo)
+			}(org, repo)
 		}
 	}
 
@@ -132,14 +306,121 @@ func (c *actionsCollector) Update(ctx context.Context, ch chan<- metrics.Metric)
 }
 
 func (c *actionsCollector) scrapeOrganizationRunners(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string) {
+	runners, err := c.listOrganizationRunners(ctx, org)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	c.collectRunners(ch, runners, "", org, "")
+}
+
+func (c *actionsCollector) scrapeOrganizationRunnerGroups(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string) {
+	groups := make([]*github.RunnerGroup, 0)
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		results, resp, err := c.client.Actions.ListOrganizationRunnerGroups(ctx, org, opts)
+		if isFeatureUnavailable(err) {
+			// Runner groups require the organization self-hosted runners
+			// permission, which is not granted to every installation
+			return
+		}
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		groups = append(groups, results.RunnerGroups...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	for _, group := range groups {
+		counts := map[string]int{
+			"busy":    0,
+			"idle":    0,
+			"offline": 0,
+		}
+		opts := &github.ListOptions{
+			PerPage: 100,
+		}
+		for {
+			results, resp, err := c.client.Actions.ListRunnerGroupRunners(ctx, org, group.GetID(), opts)
+			if err != nil {
+				sendError(errCh, err)
+				return
+			}
+			for _, runner := range results.Runners {
+				switch {
+				case runner.GetStatus() == "offline":
+					counts["offline"]++
+				case runner.GetBusy():
+					counts["busy"]++
+				default:
+					counts["idle"]++
+				}
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+
+		for status, count := range counts {
+			ch <- metrics.MustNewConstMetric(
+				c.runnerGroupsCount, metrics.GaugeValue, float64(count),
+				org, group.GetName(), status,
+			)
+		}
+	}
+}
+
+func (c *actionsCollector) scrapeRepositoryRunners(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
 	runners := make([]*github.Runner, 0)
 	opts := &github.ListOptions{
 		PerPage: 100,
 	}
 	for {
-		results, resp, err := c.client.Actions.ListOrganizationRunners(ctx, org, opts)
+		results, resp, err := c.client.Actions.ListRunners(ctx, org, repo, opts)
+		if isFeatureUnavailable(err) {
+			// Repository runners require the administration permission,
+			// which is not granted to every installation
+			return
+		}
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		runners = append(runners, results.Runners...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	// Most repositories don't have any runners registered, skip them
+	// to avoid exporting empty counts for each of them
+	if len(runners) == 0 {
+		return
+	}
+	c.collectRunners(ch, runners, "", org, repo)
+}
+
+func (c *actionsCollector) scrapeEnterpriseRunners(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, enterprise string) {
+	runners := make([]*github.Runner, 0)
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		results, resp, err := c.enterpriseClient.Enterprise.ListRunners(ctx, enterprise, opts)
+		if isFeatureUnavailable(err) {
+			// The token lacks access to runners of the enterprise
+			return
+		}
 		if err != nil {
-			errCh <- err
+			sendError(errCh, err)
 			return
 		}
 		runners = append(runners, results.Runners...)
@@ -148,7 +429,12 @@ func (c *actionsCollector) scrapeOrganizationRunners(ctx context.Context, ch cha
 		}
 		opts.Page = resp.NextPage
 	}
+	c.collectRunners(ch, runners, enterprise, "", "")
+}
 
+// collectRunners collects status, busy and idle counts of runners registered
+// in the given scope. Only one of enterprise, org or org and repo is set
+func (c *actionsCollector) collectRunners(ch chan<- metrics.Metric, runners []*github.Runner, enterprise string, org string, repo string) {
 	busy := 0
 	idle := 0
 	for _, runner := range runners {
@@ -158,9 +444,15 @@ func (c *actionsCollector) scrapeOrganizationRunners(ctx context.Context, ch cha
 		}
 		ch <- metrics.MustNewConstMetric(
 			c.runnersStatus, metrics.GaugeValue, status,
-			*runner.Name, *runner.Status, strconv.FormatBool(*runner.Busy), *runner.OS, org, "",
+			runner.GetName(), runner.GetStatus(), strconv.FormatBool(runner.GetBusy()), runner.GetOS(), org, repo, enterprise,
 		)
-		if *runner.Busy {
+		for _, label := range runner.Labels {
+			ch <- metrics.MustNewConstMetric(
+				c.runnersLabelsInfo, metrics.GaugeValue, 1,
+				runner.GetName(), label.GetName(), label.GetType(), org, repo, enterprise,
+			)
+		}
+		if runner.GetBusy() {
 			busy++
 		} else {
 			idle++
@@ -169,12 +461,212 @@ func (c *actionsCollector) scrapeOrganizationRunners(ctx context.Context, ch cha
 
 	ch <- metrics.MustNewConstMetric(
 		c.runnersBusyCount, metrics.GaugeValue, float64(busy),
-		org, "",
+		org, repo, enterprise,
 	)
 	ch <- metrics.MustNewConstMetric(
 		c.runnersIdleCount, metrics.GaugeValue, float64(idle),
+		org, repo, enterprise,
+	)
+}
+
+// scrapeOrganizationJobsDemand collects queued and in progress workflow jobs
+// of all given repositories, grouped by their requested runner labels, and
+// the organization runners able to pick them up
+func (c *actionsCollector) scrapeOrganizationJobsDemand(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repos []string) {
+	// Workflow runs and jobs are cached for longer by default, demand is
+	// used as an autoscaling signal and must be kept fresh
+	ctx = transport.WithMaxAge(ctx, actionsJobsDemandMaxAge)
+
+	mu := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+
+	demand := make(map[jobsDemandKey]int)
+	requested := make(map[string][]string)
+	for _, repo := range repos {
+		wg.Add(1)
+		go func(repo string) {
+			defer wg.Done()
+			for _, status := range []string{"queued", "in_progress"} {
+				runs, err := listWorkflowRuns(ctx, c.client, org, repo, status, time.Time{})
+				if err != nil {
+					sendError(errCh, err)
+					return
+				}
+				for _, run := range runs {
+					jobs, err := listWorkflowJobs(ctx, c.client, org, repo, run.GetID())
+					if err != nil {
+						sendError(errCh, err)
+						return
+					}
+					mu.Lock()
+					for _, job := range jobs {
+						if job.GetStatus() != "queued" && job.GetStatus() != "in_progress" {
+							continue
+						}
+						labels := joinLabels(job.Labels)
+						demand[jobsDemandKey{labels: labels, status: job.GetStatus()}]++
+						requested[labels] = job.Labels
+					}
+					mu.Unlock()
+				}
+			}
+		}(repo)
+	}
+	wg.Wait()
+
+	runners, err := c.listOrganizationRunners(ctx, org)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	for key, count := range demand {
+		ch <- metrics.MustNewConstMetric(
+			c.jobsStatusCount, metrics.GaugeValue, float64(count),
+			org, key.labels, key.status,
+		)
+	}
+	for labels, values := range requested {
+		busy := 0
+		idle := 0
+		for _, runner := range runners {
+			if runner.GetStatus() == "offline" || !runnerMatchesLabels(runner, values) {
+				continue
+			}
+			if runner.GetBusy() {
+				busy++
+			} else {
+				idle++
+			}
+		}
+		ch <- metrics.MustNewConstMetric(
+			c.jobsRunnersCount, metrics.GaugeValue, float64(busy),
+			org, labels, "true",
+		)
+		ch <- metrics.MustNewConstMetric(
+			c.jobsRunnersCount, metrics.GaugeValue, float64(idle),
+			org, labels, "false",
+		)
+	}
+}
+
+// scrapeOrganizationCacheUsage collects Github Actions cache usage of the
+// organization and of the given repositories
+func (c *actionsCollector) scrapeOrganizationCacheUsage(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repos []string) {
+	req, err := c.client.NewRequest("GET", fmt.Sprintf("orgs/%v/actions/cache/usage", org), nil)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	usage := &actionsCacheUsage{}
+	_, err = c.client.Do(ctx, req, usage)
+	if isFeatureUnavailable(err) {
+		// Cache usage requires the organization administration permission,
+		// which is not granted to every installation
+		return
+	}
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	ch <- metrics.MustNewConstMetric(
+		c.cacheActiveCount, metrics.GaugeValue, float64(usage.TotalActiveCachesCount),
+		org, "",
+	)
+	ch <- metrics.MustNewConstMetric(
+		c.cacheActiveSize, metrics.GaugeValue, float64(usage.TotalActiveCachesSizeInBytes),
 		org, "",
 	)
+
+	scraped := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		scraped[strings.ToLower(repo)] = true
+	}
+
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		u, err := addListOptions(fmt.Sprintf("orgs/%v/actions/cache/usage-by-repository", org), opts)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		req, err := c.client.NewRequest("GET", u, nil)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		results := &actionsRepositoryCacheUsages{}
+		resp, err := c.client.Do(ctx, req, results)
+		if isFeatureUnavailable(err) {
+			return
+		}
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		for _, usage := range results.RepositoryCacheUsages {
+			repo := usage.FullName[strings.Index(usage.FullName, "/")+1:]
+			if !scraped[strings.ToLower(repo)] {
+				continue
+			}
+			ch <- metrics.MustNewConstMetric(
+				c.cacheActiveCount, metrics.GaugeValue, float64(usage.ActiveCachesCount),
+				org, repo,
+			)
+			ch <- metrics.MustNewConstMetric(
+				c.cacheActiveSize, metrics.GaugeValue, float64(usage.ActiveCachesSizeInBytes),
+				org, repo,
+			)
+			ch <- metrics.MustNewConstMetric(
+				c.cacheUsageRatio, metrics.GaugeValue, float64(usage.ActiveCachesSizeInBytes)/actionsCacheLimitBytes,
+				org, repo,
+			)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (c *actionsCollector) listOrganizationRunners(ctx context.Context, org string) ([]*github.Runner, error) {
+	runners := make([]*github.Runner, 0)
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		results, resp, err := c.client.Actions.ListOrganizationRunners(ctx, org, opts)
+		if err != nil {
+			return nil, err
+		}
+		runners = append(runners, results.Runners...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return runners, nil
+}
+
+// runnerMatchesLabels reports whether the runner has all of the requested
+// labels. Labels are compared case insensitively, as Github does
+func runnerMatchesLabels(runner *github.Runner, labels []string) bool {
+	for _, label := range labels {
+		found := false
+		for _, l := range runner.Labels {
+			if strings.EqualFold(l.GetName(), label) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 func (c *actionsCollector) scrapeRepositoryWorkflowRunsByStatus(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string, status string) {
@@ -185,7 +677,7 @@ func (c *actionsCollector) scrapeRepositoryWorkflowRunsByStatus(ctx context.Cont
 		},
 	)
 	if err != nil {
-		errCh <- err
+		sendError(errCh, err)
 		return
 	}
 	ch <- metrics.MustNewConstMetric(
@@ -194,6 +686,108 @@ func (c *actionsCollector) scrapeRepositoryWorkflowRunsByStatus(ctx context.Cont
 	)
 }
 
+func (c *actionsCollector) scrapeRepositoryWorkflowRunsDurations(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	since := time.Now().Add(-viper.GetDuration("actions-runs-window"))
+	runs, err := listWorkflowRuns(ctx, c.client, org, repo, "completed", since)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	durations := make(map[workflowRunsKey][]float64)
+	queueTimes := make(map[workflowRunsKey][]float64)
+	for _, run := range runs {
+		if run.RunStartedAt == nil {
+			continue
+		}
+		key := workflowRunsKey{
+			workflow:   run.GetName(),
+			branch:     run.GetHeadBranch(),
+			event:      run.GetEvent(),
+			conclusion: run.GetConclusion(),
+		}
+		durations[key] = append(durations[key], run.GetUpdatedAt().Sub(run.GetRunStartedAt().Time).Seconds())
+		queueTimes[key] = append(queueTimes[key], run.GetRunStartedAt().Sub(run.GetCreatedAt().Time).Seconds())
+	}
+
+	for key, values := range durations {
+		ch <- newConstHistogram(
+			c.workflowRunsDuration, actionsDurationBuckets, values,
+			org, repo, key.workflow, key.branch, key.event, key.conclusion,
+		)
+	}
+	for key, values := range queueTimes {
+		ch <- newConstHistogram(
+			c.workflowRunsQueueTime, actionsQueueTimeBuckets, values,
+			org, repo, key.workflow, key.branch, key.event, key.conclusion,
+		)
+	}
+}
+
+// listWorkflowRuns lists workflow runs of the given status. If since is set,
+// workflow runs are listed from the most recently created until the first one
+// created before since
+func listWorkflowRuns(ctx context.Context, client *github.Client, org string, repo string, status string, since time.Time) ([]*github.WorkflowRun, error) {
+	runs := make([]*github.WorkflowRun, 0)
+	opts := &github.ListWorkflowRunsOptions{
+		Status: status,
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	for {
+		results, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range results.WorkflowRuns {
+			if !since.IsZero() && run.GetCreatedAt().Before(since) {
+				return runs, nil
+			}
+			runs = append(runs, run)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return runs, nil
+}
+
+func (c *actionsCollector) scrapeRepositoryArtifacts(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	counts := map[bool]int{false: 0, true: 0}
+	sizes := map[bool]int64{false: 0, true: 0}
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		results, resp, err := c.client.Actions.ListArtifacts(ctx, org, repo, opts)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		for _, artifact := range results.Artifacts {
+			counts[artifact.GetExpired()]++
+			sizes[artifact.GetExpired()] += artifact.GetSizeInBytes()
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	for expired, count := range counts {
+		ch <- metrics.MustNewConstMetric(
+			c.artifactsCount, metrics.GaugeValue, float64(count),
+			org, repo, strconv.FormatBool(expired),
+		)
+		ch <- metrics.MustNewConstMetric(
+			c.artifactsSize, metrics.GaugeValue, float64(sizes[expired]),
+			org, repo, strconv.FormatBool(expired),
+		)
+	}
+}
+
 func (c *actionsCollector) scrapeWorkflows(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
 	workflows := make([]*github.Workflow, 0)
 	opts := &github.ListOptions{
@@ -202,7 +796,7 @@ func (c *actionsCollector) scrapeWorkflows(ctx context.Context, ch chan<- metric
 	for {
 		results, resp, err := c.client.Actions.ListWorkflows(ctx, org, repo, opts)
 		if err != nil {
-			errCh <- err
+			sendError(errCh, err)
 			return
 		}
 		workflows = append(workflows, results.Workflows...)
@@ -225,5 +819,7 @@ func (c *actionsCollector) scrapeWorkflows(ctx context.Context, ch chan<- metric
 }
 
 func init() {
+	ActionsFlagset.Duration("actions-runs-window", 24*time.Hour, "Time window of workflow runs to collect duration metrics from (ACTIONS_RUNS_WINDOW)")
+
 	registerCollector("actions", true, newActionsCollector)
 }
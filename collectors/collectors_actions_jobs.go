@@ -0,0 +1,205 @@
+package collectors
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+type jobsKey struct {
+	workflow   string
+	job        string
+	conclusion string
+}
+
+type jobsRunnerKey struct {
+	workflow string
+	job      string
+	runner   string
+	labels   string
+}
+
+type jobsStepKey struct {
+	workflow string
+	job      string
+	step     string
+}
+
+type actionsJobsCollector struct {
+	jobsDuration      *metrics.Desc
+	jobsRunnerCount   *metrics.Desc
+	jobsStepsFailures *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newActionsJobsCollector(client *github.Client) (Collector, error) {
+	jobsDuration := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "jobs_duration_seconds",
+		),
+		"Duration of completed Github Action workflow jobs",
+		[]string{"org", "repo", "workflow", "job", "conclusion"}, nil,
+	)
+	jobsRunnerCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "jobs_runner_count",
+		),
+		"Total completed Github Action workflow jobs per runner",
+		[]string{"org", "repo", "workflow", "job", "runner", "labels"}, nil,
+	)
+	jobsStepsFailures := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "actions", "jobs_steps_failures_count",
+		),
+		"Total failed steps of completed Github Action workflow jobs",
+		[]string{"org", "repo", "workflow", "job", "step"}, nil,
+	)
+
+	c := &actionsJobsCollector{
+		jobsDuration:      jobsDuration,
+		jobsRunnerCount:   jobsRunnerCount,
+		jobsStepsFailures: jobsStepsFailures,
+		client:            client,
+		wg:                &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *actionsJobsCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapeWorkflowJobs(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *actionsJobsCollector) scrapeWorkflowJobs(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	since := time.Now().Add(-viper.GetDuration("actions-runs-window"))
+	runs, err := listWorkflowRuns(ctx, c.client, org, repo, "completed", since)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	durations := make(map[jobsKey][]float64)
+	runners := make(map[jobsRunnerKey]int)
+	failures := make(map[jobsStepKey]int)
+	for _, run := range runs {
+		jobs, err := listWorkflowJobs(ctx, c.client, org, repo, run.GetID())
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		for _, job := range jobs {
+			if job.StartedAt == nil || job.CompletedAt == nil {
+				continue
+			}
+			key := jobsKey{
+				workflow:   run.GetName(),
+				job:        job.GetName(),
+				conclusion: job.GetConclusion(),
+			}
+			durations[key] = append(durations[key], job.GetCompletedAt().Sub(job.GetStartedAt().Time).Seconds())
+
+			runners[jobsRunnerKey{
+				workflow: run.GetName(),
+				job:      job.GetName(),
+				runner:   job.GetRunnerName(),
+				labels:   joinLabels(job.Labels),
+			}]++
+
+			for _, step := range job.Steps {
+				if step.GetConclusion() != "failure" {
+					continue
+				}
+				failures[jobsStepKey{
+					workflow: run.GetName(),
+					job:      job.GetName(),
+					step:     step.GetName(),
+				}]++
+			}
+		}
+	}
+
+	for key, values := range durations {
+		ch <- newConstHistogram(
+			c.jobsDuration, actionsDurationBuckets, values,
+			org, repo, key.workflow, key.job, key.conclusion,
+		)
+	}
+	for key, count := range runners {
+		ch <- metrics.MustNewConstMetric(
+			c.jobsRunnerCount, metrics.GaugeValue, float64(count),
+			org, repo, key.workflow, key.job, key.runner, key.labels,
+		)
+	}
+	for key, count := range failures {
+		ch <- metrics.MustNewConstMetric(
+			c.jobsStepsFailures, metrics.GaugeValue, float64(count),
+			org, repo, key.workflow, key.job, key.step,
+		)
+	}
+}
+
+// listWorkflowJobs lists jobs from the most recent attempt of the workflow run
+func listWorkflowJobs(ctx context.Context, client *github.Client, org string, repo string, runID int64) ([]*github.WorkflowJob, error) {
+	jobs := make([]*github.WorkflowJob, 0)
+	opts := &github.ListWorkflowJobsOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	for {
+		results, resp, err := client.Actions.ListWorkflowJobs(ctx, org, repo, runID, opts)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, results.Jobs...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return jobs, nil
+}
+
+// joinLabels returns sorted runner labels joined into a single label value
+func joinLabels(labels []string) string {
+	sorted := make([]string, len(labels))
+	copy(sorted, labels)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func init() {
+	registerCollector("actions_jobs", false, newActionsJobsCollector)
+}
@@ -0,0 +1,104 @@
+package collectors
+
+import (
+	"context"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+type billingCollector struct {
+	actionsMinutesUsed          *metrics.Desc
+	actionsPaidMinutesUsed      *metrics.Desc
+	actionsIncludedMinutes      *metrics.Desc
+	packagesBandwidthUsed       *metrics.Desc
+	packagesPaidBandwidthUsed   *metrics.Desc
+	packagesIncludedBandwidth   *metrics.Desc
+	storageEstimatedUsage       *metrics.Desc
+	storageEstimatedPaidUsage   *metrics.Desc
+	storageDaysLeftBillingCycle *metrics.Desc
+
+	client *github.Client
+}
+
+func newBillingCollector(client *github.Client) (Collector, error) {
+	c := &billingCollector{
+		actionsMinutesUsed: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "billing", "actions_minutes_used"),
+			"Total Github Actions minutes used in the current billing cycle", []string{"org", "os"}, nil,
+		),
+		actionsPaidMinutesUsed: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "billing", "actions_paid_minutes_used"),
+			"Total paid Github Actions minutes used in the current billing cycle", []string{"org"}, nil,
+		),
+		actionsIncludedMinutes: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "billing", "actions_included_minutes"),
+			"Total Github Actions minutes included in the plan", []string{"org"}, nil,
+		),
+		packagesBandwidthUsed: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "billing", "packages_bandwidth_used_gigabytes"),
+			"Total Github Packages bandwidth used in the current billing cycle", []string{"org"}, nil,
+		),
+		packagesPaidBandwidthUsed: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "billing", "packages_paid_bandwidth_used_gigabytes"),
+			"Total paid Github Packages bandwidth used in the current billing cycle", []string{"org"}, nil,
+		),
+		packagesIncludedBandwidth: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "billing", "packages_included_bandwidth_gigabytes"),
+			"Total Github Packages bandwidth included in the plan", []string{"org"}, nil,
+		),
+		storageEstimatedUsage: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "billing", "shared_storage_estimated_gigabytes"),
+			"Estimated shared storage usage of Github Actions and Packages for the month", []string{"org"}, nil,
+		),
+		storageEstimatedPaidUsage: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "billing", "shared_storage_estimated_paid_gigabytes"),
+			"Estimated paid shared storage usage of Github Actions and Packages for the month", []string{"org"}, nil,
+		),
+		storageDaysLeftBillingCycle: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "billing", "days_left_in_billing_cycle"),
+			"Days left in the current billing cycle", []string{"org"}, nil,
+		),
+		client: client,
+	}
+
+	return c, nil
+}
+
+func (c *billingCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		actions, _, err := c.client.Billing.GetActionsBillingOrg(ctx, org)
+		if err != nil {
+			return err
+		}
+		packages, _, err := c.client.Billing.GetPackagesBillingOrg(ctx, org)
+		if err != nil {
+			return err
+		}
+		storage, _, err := c.client.Billing.GetStorageBillingOrg(ctx, org)
+		if err != nil {
+			return err
+		}
+
+		ch <- metrics.MustNewConstMetric(c.actionsMinutesUsed, metrics.GaugeValue, float64(actions.MinutesUsedBreakdown.Ubuntu), org, "UBUNTU")
+		ch <- metrics.MustNewConstMetric(c.actionsMinutesUsed, metrics.GaugeValue, float64(actions.MinutesUsedBreakdown.MacOS), org, "MACOS")
+		ch <- metrics.MustNewConstMetric(c.actionsMinutesUsed, metrics.GaugeValue, float64(actions.MinutesUsedBreakdown.Windows), org, "WINDOWS")
+		ch <- metrics.MustNewConstMetric(c.actionsPaidMinutesUsed, metrics.GaugeValue, float64(actions.TotalPaidMinutesUsed), org)
+		ch <- metrics.MustNewConstMetric(c.actionsIncludedMinutes, metrics.GaugeValue, float64(actions.IncludedMinutes), org)
+
+		ch <- metrics.MustNewConstMetric(c.packagesBandwidthUsed, metrics.GaugeValue, float64(packages.TotalGigabytesBandwidthUsed), org)
+		ch <- metrics.MustNewConstMetric(c.packagesPaidBandwidthUsed, metrics.GaugeValue, float64(packages.TotalPaidGigabytesBandwidthUsed), org)
+		ch <- metrics.MustNewConstMetric(c.packagesIncludedBandwidth, metrics.GaugeValue, float64(packages.IncludedGigabytesBandwidth), org)
+
+		ch <- metrics.MustNewConstMetric(c.storageEstimatedUsage, metrics.GaugeValue, float64(storage.EstimatedStorageForMonth), org)
+		ch <- metrics.MustNewConstMetric(c.storageEstimatedPaidUsage, metrics.GaugeValue, storage.EstimatedPaidStorageForMonth, org)
+		ch <- metrics.MustNewConstMetric(c.storageDaysLeftBillingCycle, metrics.GaugeValue, float64(storage.DaysLeftInBillingCycle), org)
+	}
+
+	return nil
+}
+
+func init() {
+	registerCollector("billing", false, newBillingCollector)
+}
@@ -0,0 +1,145 @@
+package collectors
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+type branchProtectionCollector struct {
+	protectionEnabled    *metrics.Desc
+	ruleEnabled          *metrics.Desc
+	requiredReviewsCount *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newBranchProtectionCollector(client *github.Client) (Collector, error) {
+	protectionEnabled := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "branch_protection", "enabled",
+		),
+		"Whether branch protection is enabled for the default branch",
+		[]string{"org", "repo", "branch"}, nil,
+	)
+	ruleEnabled := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "branch_protection", "rule_enabled",
+		),
+		"Whether branch protection rule is enabled for the default branch",
+		[]string{"org", "repo", "branch", "rule"}, nil,
+	)
+	requiredReviewsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "branch_protection", "required_reviews_count",
+		),
+		"Required approving reviews count of pull requests to the default branch",
+		[]string{"org", "repo", "branch"}, nil,
+	)
+
+	c := &branchProtectionCollector{
+		protectionEnabled:    protectionEnabled,
+		ruleEnabled:          ruleEnabled,
+		requiredReviewsCount: requiredReviewsCount,
+		client:               client,
+		wg:                   &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *branchProtectionCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := getRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string, branch string) {
+				c.scrapeBranchProtection(ctx, ch, errCh, org, repo, branch)
+				c.wg.Done()
+			}(org, repo.GetName(), repo.GetDefaultBranch())
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *branchProtectionCollector) scrapeBranchProtection(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string, branch string) {
+	protection, _, err := c.client.Repositories.GetBranchProtection(ctx, org, repo, branch)
+	switch {
+	case err == github.ErrBranchNotProtected:
+	case isPlanUnsupported(err):
+		// Private repositories can't be protected on the Github Free plan
+	case isNotFound(err):
+		// Empty repositories have no default branch yet
+		return
+	case err != nil:
+		sendError(errCh, err)
+		return
+	}
+
+	rules := map[string]bool{
+		"required_reviews":       false,
+		"required_status_checks": false,
+		"enforce_admins":         false,
+		"signed_commits":         false,
+		"linear_history":         false,
+	}
+	requiredReviews := 0
+
+	if protection != nil {
+		signatures, _, err := c.client.Repositories.GetSignaturesProtectedBranch(ctx, org, repo, branch)
+		if err != nil && !isFeatureUnavailable(err) {
+			sendError(errCh, err)
+			return
+		}
+
+		if protection.RequiredPullRequestReviews != nil {
+			rules["required_reviews"] = true
+			requiredReviews = protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+		}
+		if protection.EnforceAdmins != nil {
+			rules["enforce_admins"] = protection.EnforceAdmins.Enabled
+		}
+		if protection.RequireLinearHistory != nil {
+			rules["linear_history"] = protection.RequireLinearHistory.Enabled
+		}
+		rules["required_status_checks"] = protection.RequiredStatusChecks != nil
+		rules["signed_commits"] = signatures.GetEnabled()
+	}
+
+	ch <- metrics.MustNewConstMetric(
+		c.protectionEnabled, metrics.GaugeValue, boolToFloat64(protection != nil),
+		org, repo, branch,
+	)
+	for rule, enabled := range rules {
+		ch <- metrics.MustNewConstMetric(
+			c.ruleEnabled, metrics.GaugeValue, boolToFloat64(enabled),
+			org, repo, branch, rule,
+		)
+	}
+	ch <- metrics.MustNewConstMetric(
+		c.requiredReviewsCount, metrics.GaugeValue, float64(requiredReviews),
+		org, repo, branch,
+	)
+}
+
+func init() {
+	registerCollector("branch_protection", false, newBranchProtectionCollector)
+}
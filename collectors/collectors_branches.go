@@ -0,0 +1,235 @@
+package collectors
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	"github.com/pkg/errors"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var (
+	BranchesFlagset = pflag.NewFlagSet("branches", pflag.ExitOnError)
+)
+
+// Maximum concurrent requests per repository, resolving commit dates of
+// branches on the first scrape
+const branchesCommitsConcurrency = 10
+
+type branchesCollector struct {
+	branchesCount     *metrics.Desc
+	staleCount        *metrics.Desc
+	withoutPullsCount *metrics.Desc
+
+	staleThresholds map[string]time.Duration
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newBranchesCollector(client *github.Client) (Collector, error) {
+	staleThresholds := make(map[string]time.Duration)
+	for _, threshold := range viper.GetStringSlice("branches-stale-thresholds") {
+		d, err := time.ParseDuration(threshold)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing branches stale threshold %q", threshold)
+		}
+		staleThresholds[threshold] = d
+	}
+
+	branchesCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "branches", "count",
+		),
+		"Total repository branches",
+		[]string{"org", "repo"}, nil,
+	)
+	staleCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "branches", "stale_count",
+		),
+		"Total branches, other than the default one, whose last commit is older than the threshold",
+		[]string{"org", "repo", "threshold"}, nil,
+	)
+	withoutPullsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "branches", "without_pull_request_count",
+		),
+		"Total branches, other than the default one, without an open pull request",
+		[]string{"org", "repo"}, nil,
+	)
+
+	c := &branchesCollector{
+		branchesCount:     branchesCount,
+		staleCount:        staleCount,
+		withoutPullsCount: withoutPullsCount,
+		staleThresholds:   staleThresholds,
+		client:            client,
+		wg:                &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *branchesCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := getRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string, defaultBranch string) {
+				c.scrapeBranches(ctx, ch, errCh, org, repo, defaultBranch)
+				c.wg.Done()
+			}(org, repo.GetName(), repo.GetDefaultBranch())
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *branchesCollector) scrapeBranches(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string, defaultBranch string) {
+	branches, err := c.listBranches(ctx, org, repo)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	pulls, err := listPullRequests(ctx, c.client, org, repo, "open", time.Time{})
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	heads := make(map[string]bool)
+	for _, pull := range pulls {
+		head := pull.GetHead().GetRepo()
+		if strings.EqualFold(head.GetName(), repo) && strings.EqualFold(head.GetOwner().GetLogin(), org) {
+			heads[pull.GetHead().GetRef()] = true
+		}
+	}
+
+	now := time.Now()
+	staleCounts := make(map[string]int)
+	withoutPulls := 0
+	for name, committed := range branches {
+		if name == defaultBranch {
+			continue
+		}
+		if !heads[name] {
+			withoutPulls++
+		}
+		for threshold, d := range c.staleThresholds {
+			if committed.Before(now.Add(-d)) {
+				staleCounts[threshold]++
+			}
+		}
+	}
+
+	ch <- metrics.MustNewConstMetric(
+		c.branchesCount, metrics.GaugeValue, float64(len(branches)),
+		org, repo,
+	)
+	ch <- metrics.MustNewConstMetric(
+		c.withoutPullsCount, metrics.GaugeValue, float64(withoutPulls),
+		org, repo,
+	)
+	for threshold := range c.staleThresholds {
+		ch <- metrics.MustNewConstMetric(
+			c.staleCount, metrics.GaugeValue, float64(staleCounts[threshold]),
+			org, repo, threshold,
+		)
+	}
+}
+
+// listBranches returns last commit dates of all repository branches, keyed by
+// branch name. Listed branches only contain the commit SHA, commit dates are
+// resolved per SHA, as commits never change and are served from cache
+func (c *branchesCollector) listBranches(ctx context.Context, org string, repo string) (map[string]time.Time, error) {
+	heads := make(map[string]string)
+	opts := &github.BranchListOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	for {
+		results, resp, err := c.client.Repositories.ListBranches(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range results {
+			heads[branch.GetName()] = branch.GetCommit().GetSHA()
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	mu := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	errCh := make(chan error, 1)
+	sem := make(chan struct{}, branchesCommitsConcurrency)
+
+	dates := make(map[string]time.Time)
+	for _, sha := range heads {
+		mu.Lock()
+		_, ok := dates[sha]
+		dates[sha] = time.Time{}
+		mu.Unlock()
+		if ok {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(sha string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			commit, _, err := c.client.Git.GetCommit(ctx, org, repo, sha)
+			if err != nil {
+				sendError(errCh, err)
+				return
+			}
+			mu.Lock()
+			dates[sha] = commit.GetCommitter().GetDate()
+			mu.Unlock()
+		}(sha)
+	}
+	wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return nil, err
+	default:
+	}
+
+	branches := make(map[string]time.Time, len(heads))
+	for name, sha := range heads {
+		branches[name] = dates[sha]
+	}
+	return branches, nil
+}
+
+func init() {
+	BranchesFlagset.StringSlice("branches-stale-thresholds", []string{"720h", "2160h", "4320h"}, "List of durations since the last commit after which branches are considered stale (BRANCHES_STALE_THRESHOLDS)")
+
+	registerCollector("branches", false, newBranchesCollector)
+}
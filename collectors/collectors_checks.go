@@ -0,0 +1,182 @@
+package collectors
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+type checkRunsKey struct {
+	name       string
+	app        string
+	status     string
+	conclusion string
+}
+
+type checksCollector struct {
+	green          *metrics.Desc
+	combinedStatus *metrics.Desc
+	commitStatus   *metrics.Desc
+	checkRunsCount *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newChecksCollector(client *github.Client) (Collector, error) {
+	green := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "checks", "green",
+		),
+		"Whether all commit statuses and check runs of the default branch HEAD succeeded",
+		[]string{"org", "repo", "branch"}, nil,
+	)
+	combinedStatus := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "checks", "combined_status",
+		),
+		"Combined commit status of the default branch HEAD",
+		[]string{"org", "repo", "branch", "state"}, nil,
+	)
+	commitStatus := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "checks", "commit_status",
+		),
+		"Commit status of the default branch HEAD per context",
+		[]string{"org", "repo", "branch", "context", "state"}, nil,
+	)
+	checkRunsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "checks", "check_runs_count",
+		),
+		"Total check runs of the default branch HEAD per name, app, status and conclusion",
+		[]string{"org", "repo", "branch", "name", "app", "status", "conclusion"}, nil,
+	)
+
+	c := &checksCollector{
+		green:          green,
+		combinedStatus: combinedStatus,
+		commitStatus:   commitStatus,
+		checkRunsCount: checkRunsCount,
+		client:         client,
+		wg:             &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *checksCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := getRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string, branch string) {
+				c.scrapeChecks(ctx, ch, errCh, org, repo, branch)
+				c.wg.Done()
+			}(org, repo.GetName(), repo.GetDefaultBranch())
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *checksCollector) scrapeChecks(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string, branch string) {
+	// Resolve the branch HEAD once, so both statuses and check runs are read
+	// for the same commit
+	sha, _, err := c.client.Repositories.GetCommitSHA1(ctx, org, repo, branch, "")
+	if isFeatureUnavailable(err) || isRepositoryEmpty(err) {
+		// Empty repositories have no default branch yet
+		return
+	}
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	combined, _, err := c.client.Repositories.GetCombinedStatus(ctx, org, repo, sha, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	runs := make([]*github.CheckRun, 0)
+	opts := &github.ListCheckRunsOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	for {
+		results, resp, err := c.client.Checks.ListCheckRunsForRef(ctx, org, repo, sha, opts)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		runs = append(runs, results.CheckRuns...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	// Combined status is pending if there are no commit statuses at all
+	green := combined.GetState() == "success" || combined.GetTotalCount() == 0
+
+	ch <- metrics.MustNewConstMetric(
+		c.combinedStatus, metrics.GaugeValue, 1,
+		org, repo, branch, combined.GetState(),
+	)
+	for _, status := range combined.Statuses {
+		ch <- metrics.MustNewConstMetric(
+			c.commitStatus, metrics.GaugeValue, 1,
+			org, repo, branch, status.GetContext(), status.GetState(),
+		)
+	}
+
+	// Check runs of the same name may be reported by several check suites
+	runsCounts := make(map[checkRunsKey]int)
+	for _, run := range runs {
+		switch run.GetConclusion() {
+		case "success", "neutral", "skipped":
+		default:
+			green = false
+		}
+		key := checkRunsKey{
+			name:       run.GetName(),
+			app:        run.GetApp().GetSlug(),
+			status:     run.GetStatus(),
+			conclusion: run.GetConclusion(),
+		}
+		runsCounts[key]++
+	}
+	for key, count := range runsCounts {
+		ch <- metrics.MustNewConstMetric(
+			c.checkRunsCount, metrics.GaugeValue, float64(count),
+			org, repo, branch, key.name, key.app, key.status, key.conclusion,
+		)
+	}
+
+	ch <- metrics.MustNewConstMetric(
+		c.green, metrics.GaugeValue, boolToFloat64(green),
+		org, repo, branch,
+	)
+}
+
+func init() {
+	registerCollector("checks", false, newChecksCollector)
+}
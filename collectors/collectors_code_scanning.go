@@ -0,0 +1,171 @@
+package collectors
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+type codeScanningKey struct {
+	tool     string
+	severity string
+	state    string
+}
+
+type codeScanningAnalysisKey struct {
+	ref  string
+	tool string
+}
+
+type codeScanningCollector struct {
+	alertsCount  *metrics.Desc
+	lastAnalysis *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newCodeScanningCollector(client *github.Client) (Collector, error) {
+	alertsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "code_scanning", "alerts_count",
+		),
+		"Total code scanning alerts",
+		[]string{"org", "repo", "tool", "severity", "state"}, nil,
+	)
+	lastAnalysis := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "code_scanning", "last_analysis_timestamp_seconds",
+		),
+		"Time of the last code scanning analysis",
+		[]string{"org", "repo", "ref", "tool"}, nil,
+	)
+
+	c := &codeScanningCollector{
+		alertsCount:  alertsCount,
+		lastAnalysis: lastAnalysis,
+		client:       client,
+		wg:           &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *codeScanningCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapeAlerts(ctx, ch, errCh, org, repo)
+				c.scrapeAnalyses(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+// scrapeAlerts collects code scanning alerts of the repository. Repositories
+// without code scanning enabled are skipped
+func (c *codeScanningCollector) scrapeAlerts(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	counts := make(map[codeScanningKey]int)
+	opts := &github.AlertListOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	for {
+		results, resp, err := c.client.CodeScanning.ListAlertsForRepo(ctx, org, repo, opts)
+		if isFeatureUnavailable(err) {
+			return
+		}
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		for _, alert := range results {
+			severity := alert.GetRule().GetSeverity()
+			if severity == "" {
+				severity = alert.GetRuleSeverity()
+			}
+			counts[codeScanningKey{
+				tool:     alert.GetTool().GetName(),
+				severity: severity,
+				state:    alert.GetState(),
+			}]++
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	for key, count := range counts {
+		ch <- metrics.MustNewConstMetric(
+			c.alertsCount, metrics.GaugeValue, float64(count),
+			org, repo, key.tool, key.severity, key.state,
+		)
+	}
+}
+
+// scrapeAnalyses collects the time of the last analysis per ref and tool.
+// Analyses are listed from the most recent one, so only the first page is
+// considered. Repositories without code scanning enabled are skipped
+func (c *codeScanningCollector) scrapeAnalyses(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	results, _, err := c.client.CodeScanning.ListAnalysesForRepo(
+		ctx, org, repo,
+		&github.AnalysesListOptions{
+			ListOptions: github.ListOptions{
+				PerPage: 100,
+			},
+		},
+	)
+	if isFeatureUnavailable(err) {
+		return
+	}
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	last := make(map[codeScanningAnalysisKey]float64)
+	for _, analysis := range results {
+		key := codeScanningAnalysisKey{
+			ref:  analysis.GetRef(),
+			tool: analysis.GetTool().GetName(),
+		}
+		created := float64(analysis.GetCreatedAt().Unix())
+		if created > last[key] {
+			last[key] = created
+		}
+	}
+
+	for key, created := range last {
+		ch <- metrics.MustNewConstMetric(
+			c.lastAnalysis, metrics.GaugeValue, created,
+			org, repo, key.ref, key.tool,
+		)
+	}
+}
+
+func init() {
+	registerCollector("code_scanning", false, newCodeScanningCollector)
+}
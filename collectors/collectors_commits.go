@@ -0,0 +1,215 @@
+package collectors
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var (
+	CommitsFlagset = pflag.NewFlagSet("commits", pflag.ExitOnError)
+)
+
+type commitsCollector struct {
+	commitsCount    *metrics.Desc
+	commitsPerDay   *metrics.Desc
+	authorsCount    *metrics.Desc
+	weeklyAdditions *metrics.Desc
+	weeklyDeletions *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newCommitsCollector(client *github.Client) (Collector, error) {
+	commitsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "commits", "count",
+		),
+		"Total commits to the default branch within the configured window",
+		[]string{"org", "repo", "branch"}, nil,
+	)
+	commitsPerDay := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "commits", "per_day",
+		),
+		"Average commits to the default branch per day within the configured window",
+		[]string{"org", "repo", "branch"}, nil,
+	)
+	authorsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "commits", "authors_count",
+		),
+		"Total unique authors of commits to the default branch within the configured window",
+		[]string{"org", "repo", "branch"}, nil,
+	)
+	weeklyAdditions := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "commits", "weekly_additions",
+		),
+		"Lines added to the repository during the current week",
+		[]string{"org", "repo"}, nil,
+	)
+	weeklyDeletions := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "commits", "weekly_deletions",
+		),
+		"Lines deleted from the repository during the current week",
+		[]string{"org", "repo"}, nil,
+	)
+
+	c := &commitsCollector{
+		commitsCount:    commitsCount,
+		commitsPerDay:   commitsPerDay,
+		authorsCount:    authorsCount,
+		weeklyAdditions: weeklyAdditions,
+		weeklyDeletions: weeklyDeletions,
+		client:          client,
+		wg:              &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *commitsCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := getRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string, branch string) {
+				c.scrapeCommits(ctx, ch, errCh, org, repo, branch)
+				c.scrapeCodeFrequency(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo.GetName(), repo.GetDefaultBranch())
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *commitsCollector) scrapeCommits(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string, branch string) {
+	window := viper.GetDuration("commits-window")
+	since := time.Now().Add(-window)
+
+	commits, err := listCommits(ctx, c.client, org, repo, branch, since)
+	if isFeatureUnavailable(err) || isRepositoryEmpty(err) {
+		// Empty repositories have no default branch yet
+		return
+	}
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	authors := make(map[string]bool)
+	for _, commit := range commits {
+		author := commit.GetAuthor().GetLogin()
+		if author == "" {
+			author = commit.GetCommit().GetAuthor().GetEmail()
+		}
+		authors[author] = true
+	}
+
+	ch <- metrics.MustNewConstMetric(
+		c.commitsCount, metrics.GaugeValue, float64(len(commits)),
+		org, repo, branch,
+	)
+	ch <- metrics.MustNewConstMetric(
+		c.commitsPerDay, metrics.GaugeValue, float64(len(commits))/window.Hours()*24,
+		org, repo, branch,
+	)
+	ch <- metrics.MustNewConstMetric(
+		c.authorsCount, metrics.GaugeValue, float64(len(authors)),
+		org, repo, branch,
+	)
+}
+
+func (c *commitsCollector) scrapeCodeFrequency(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	weeks, _, err := c.client.Repositories.ListCodeFrequency(ctx, org, repo)
+	// Statistics are computed in the background on first request, they will
+	// be available on one of the following scrapes
+	if _, ok := err.(*github.AcceptedError); ok {
+		return
+	}
+	// Statistics are not computed for repositories with 10,000 or more commits
+	if e, ok := err.(*github.ErrorResponse); ok && e.Response != nil && e.Response.StatusCode == http.StatusUnprocessableEntity {
+		return
+	}
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	if len(weeks) == 0 {
+		return
+	}
+
+	// Weeks are sorted from the oldest to the current one
+	week := weeks[len(weeks)-1]
+	deletions := week.GetDeletions()
+	if deletions < 0 {
+		deletions = -deletions
+	}
+
+	ch <- metrics.MustNewConstMetric(
+		c.weeklyAdditions, metrics.GaugeValue, float64(week.GetAdditions()),
+		org, repo,
+	)
+	ch <- metrics.MustNewConstMetric(
+		c.weeklyDeletions, metrics.GaugeValue, float64(deletions),
+		org, repo,
+	)
+}
+
+// listCommits lists commits of the branch from the most recent one until the
+// first one committed before since
+func listCommits(ctx context.Context, client *github.Client, org string, repo string, branch string, since time.Time) ([]*github.RepositoryCommit, error) {
+	commits := make([]*github.RepositoryCommit, 0)
+	opts := &github.CommitsListOptions{
+		SHA: branch,
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	for {
+		results, resp, err := client.Repositories.ListCommits(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range results {
+			if commit.GetCommit().GetCommitter().GetDate().Before(since) {
+				return commits, nil
+			}
+			commits = append(commits, commit)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return commits, nil
+}
+
+func init() {
+	CommitsFlagset.Duration("commits-window", 7*24*time.Hour, "Time window of default branch commits to collect metrics from (COMMITS_WINDOW)")
+
+	registerCollector("commits", false, newCommitsCollector)
+}
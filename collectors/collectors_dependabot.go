@@ -0,0 +1,162 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+// dependabotAlert represents a Dependabot alert of a repository
+type dependabotAlert struct {
+	Number     int       `json:"number"`
+	State      string    `json:"state"`
+	CreatedAt  time.Time `json:"created_at"`
+	Dependency struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+	} `json:"dependency"`
+	SecurityAdvisory struct {
+		Severity string `json:"severity"`
+	} `json:"security_advisory"`
+}
+
+type dependabotKey struct {
+	severity  string
+	ecosystem string
+	state     string
+}
+
+type dependabotCollector struct {
+	alertsCount            *metrics.Desc
+	oldestCriticalAlertAge *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newDependabotCollector(client *github.Client) (Collector, error) {
+	alertsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "dependabot", "alerts_count",
+		),
+		"Total Dependabot alerts",
+		[]string{"org", "repo", "severity", "ecosystem", "state"}, nil,
+	)
+	oldestCriticalAlertAge := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "dependabot", "oldest_open_critical_alert_age_seconds",
+		),
+		"Age of the oldest open critical Dependabot alert, 0 if there are none",
+		[]string{"org", "repo"}, nil,
+	)
+
+	c := &dependabotCollector{
+		alertsCount:            alertsCount,
+		oldestCriticalAlertAge: oldestCriticalAlertAge,
+		client:                 client,
+		wg:                     &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *dependabotCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapeAlerts(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *dependabotCollector) scrapeAlerts(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	alerts := make([]*dependabotAlert, 0)
+	// Dependabot alerts are paginated with cursors, page numbers are ignored
+	opts := &github.ListCursorOptions{
+		PerPage: 100,
+	}
+	for {
+		u, err := addListCursorOptions(fmt.Sprintf("repos/%v/%v/dependabot/alerts", org, repo), opts)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		req, err := c.client.NewRequest("GET", u, nil)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		results := make([]*dependabotAlert, 0)
+		resp, err := c.client.Do(ctx, req, &results)
+		if isFeatureUnavailable(err) {
+			return
+		}
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		alerts = append(alerts, results...)
+		if resp.After == "" {
+			break
+		}
+		opts.After = resp.After
+	}
+
+	now := time.Now()
+	oldest := 0.0
+	counts := make(map[dependabotKey]int)
+	for _, alert := range alerts {
+		counts[dependabotKey{
+			severity:  alert.SecurityAdvisory.Severity,
+			ecosystem: alert.Dependency.Package.Ecosystem,
+			state:     alert.State,
+		}]++
+		if alert.State == "open" && alert.SecurityAdvisory.Severity == "critical" {
+			age := now.Sub(alert.CreatedAt).Seconds()
+			if age > oldest {
+				oldest = age
+			}
+		}
+	}
+
+	for key, count := range counts {
+		ch <- metrics.MustNewConstMetric(
+			c.alertsCount, metrics.GaugeValue, float64(count),
+			org, repo, key.severity, key.ecosystem, key.state,
+		)
+	}
+	ch <- metrics.MustNewConstMetric(
+		c.oldestCriticalAlertAge, metrics.GaugeValue, oldest,
+		org, repo,
+	)
+}
+
+func init() {
+	registerCollector("dependabot", false, newDependabotCollector)
+}
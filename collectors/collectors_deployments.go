@@ -0,0 +1,333 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var (
+	DeploymentsFlagset = pflag.NewFlagSet("deployments", pflag.ExitOnError)
+
+	// Buckets of deployments duration, from 30 seconds up to 2 hours
+	deploymentsDurationBuckets = []float64{30, 60, 120, 300, 600, 900, 1800, 3600, 7200}
+	// Buckets of deployments review wait time, from 1 minute up to 1 day
+	deploymentsReviewWaitBuckets = []float64{60, 300, 900, 1800, 3600, 4 * 3600, 12 * 3600, 24 * 3600}
+)
+
+// deploymentsApproval represents a review of environments deployed to by a
+// workflow run
+type deploymentsApproval struct {
+	Environments []struct {
+		Name string `json:"name"`
+	} `json:"environments"`
+}
+
+type deploymentsCollector struct {
+	deploymentsCount   *metrics.Desc
+	latestStatus       *metrics.Desc
+	deploymentDuration *metrics.Desc
+	reviewWait         *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newDeploymentsCollector(client *github.Client) (Collector, error) {
+	deploymentsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "deployments", "count",
+		),
+		"Total deployments created within the configured window",
+		[]string{"org", "repo", "environment"}, nil,
+	)
+	latestStatus := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "deployments", "latest_status",
+		),
+		"Status of the latest deployment created within the configured window",
+		[]string{"org", "repo", "environment", "ref", "state"}, nil,
+	)
+	deploymentDuration := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "deployments", "duration_seconds",
+		),
+		"Time from deployment creation to its first successful status",
+		[]string{"org", "repo", "environment"}, nil,
+	)
+	reviewWait := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "deployments", "review_wait_seconds",
+		),
+		"Time deployments to environments with required reviewers waited for a review",
+		[]string{"org", "repo", "environment"}, nil,
+	)
+
+	c := &deploymentsCollector{
+		deploymentsCount:   deploymentsCount,
+		latestStatus:       latestStatus,
+		deploymentDuration: deploymentDuration,
+		reviewWait:         reviewWait,
+		client:             client,
+		wg:                 &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *deploymentsCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapeDeployments(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *deploymentsCollector) scrapeDeployments(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	since := time.Now().Add(-viper.GetDuration("deployments-window"))
+	deployments, err := listDeployments(ctx, c.client, org, repo, since)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	if len(deployments) == 0 {
+		return
+	}
+
+	protected, err := c.listProtectedEnvironments(ctx, org, repo)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	// Workflow runs are only needed to find deployments waiting for a review
+	runs := make([]*github.WorkflowRun, 0)
+	if len(protected) > 0 {
+		runs, err = listWorkflowRuns(ctx, c.client, org, repo, "", since)
+		if err != nil && !isFeatureUnavailable(err) {
+			sendError(errCh, err)
+			return
+		}
+	}
+	reviewed := make(map[int64]map[string]bool)
+
+	counts := make(map[string]int)
+	durations := make(map[string][]float64)
+	reviewWaits := make(map[string][]float64)
+	for _, deployment := range deployments {
+		environment := deployment.GetEnvironment()
+		statuses, err := listDeploymentStatuses(ctx, c.client, org, repo, deployment.GetID())
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+
+		// Deployments are listed from the most recent one
+		if _, ok := counts[environment]; !ok {
+			state := ""
+			if len(statuses) > 0 {
+				state = statuses[len(statuses)-1].GetState()
+			}
+			ch <- metrics.MustNewConstMetric(
+				c.latestStatus, metrics.GaugeValue, 1,
+				org, repo, environment, deployment.GetRef(), state,
+			)
+		}
+		counts[environment]++
+
+		if success := findDeploymentStatus(statuses, "success"); success != nil {
+			durations[environment] = append(durations[environment], success.GetCreatedAt().Sub(deployment.GetCreatedAt().Time).Seconds())
+		}
+		if run := findDeploymentWorkflowRun(runs, deployment); protected[environment] && run != nil {
+			if _, ok := reviewed[run.GetID()]; !ok {
+				reviewed[run.GetID()], err = c.listReviewedEnvironments(ctx, org, repo, run.GetID())
+				if err != nil {
+					sendError(errCh, err)
+					return
+				}
+			}
+			if wait := reviewWaitTime(deployment, statuses); reviewed[run.GetID()][environment] && wait > 0 {
+				reviewWaits[environment] = append(reviewWaits[environment], wait)
+			}
+		}
+	}
+
+	for environment, count := range counts {
+		ch <- metrics.MustNewConstMetric(
+			c.deploymentsCount, metrics.GaugeValue, float64(count),
+			org, repo, environment,
+		)
+		ch <- newConstHistogram(c.deploymentDuration, deploymentsDurationBuckets, durations[environment], org, repo, environment)
+		if protected[environment] {
+			ch <- newConstHistogram(c.reviewWait, deploymentsReviewWaitBuckets, reviewWaits[environment], org, repo, environment)
+		}
+	}
+}
+
+// listProtectedEnvironments returns names of repository environments with
+// required reviewers protection rule
+func (c *deploymentsCollector) listProtectedEnvironments(ctx context.Context, org string, repo string) (map[string]bool, error) {
+	protected := make(map[string]bool)
+	results, _, err := c.client.Repositories.ListEnvironments(ctx, org, repo)
+	if isFeatureUnavailable(err) {
+		return protected, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, environment := range results.Environments {
+		for _, rule := range environment.ProtectionRules {
+			if rule.GetType() == "required_reviewers" {
+				protected[environment.GetName()] = true
+			}
+		}
+	}
+	return protected, nil
+}
+
+// listDeployments lists deployments from the most recently created until the
+// first one created before since
+func listDeployments(ctx context.Context, client *github.Client, org string, repo string, since time.Time) ([]*github.Deployment, error) {
+	deployments := make([]*github.Deployment, 0)
+	opts := &github.DeploymentsListOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	for {
+		results, resp, err := client.Repositories.ListDeployments(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, deployment := range results {
+			if deployment.GetCreatedAt().Before(since) {
+				return deployments, nil
+			}
+			deployments = append(deployments, deployment)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return deployments, nil
+}
+
+// listDeploymentStatuses lists statuses of the deployment, sorted from the
+// oldest to the most recent one
+func listDeploymentStatuses(ctx context.Context, client *github.Client, org string, repo string, id int64) ([]*github.DeploymentStatus, error) {
+	statuses := make([]*github.DeploymentStatus, 0)
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		results, resp, err := client.Repositories.ListDeploymentStatuses(ctx, org, repo, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, results...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].GetCreatedAt().Before(statuses[j].GetCreatedAt().Time)
+	})
+	return statuses, nil
+}
+
+// findDeploymentStatus returns the first status of the given state
+func findDeploymentStatus(statuses []*github.DeploymentStatus, state string) *github.DeploymentStatus {
+	for _, status := range statuses {
+		if status.GetState() == state {
+			return status
+		}
+	}
+	return nil
+}
+
+// listReviewedEnvironments returns names of environments reviewed, either
+// approved or rejected, during the workflow run
+func (c *deploymentsCollector) listReviewedEnvironments(ctx context.Context, org string, repo string, id int64) (map[string]bool, error) {
+	req, err := c.client.NewRequest("GET", fmt.Sprintf("repos/%v/%v/actions/runs/%v/approvals", org, repo, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	approvals := make([]*deploymentsApproval, 0)
+	_, err = c.client.Do(ctx, req, &approvals)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewed := make(map[string]bool)
+	for _, approval := range approvals {
+		for _, environment := range approval.Environments {
+			reviewed[environment.Name] = true
+		}
+	}
+	return reviewed, nil
+}
+
+// findDeploymentWorkflowRun returns the workflow run which created the
+// deployment, nil if the deployment wasn't created by a workflow run
+func findDeploymentWorkflowRun(runs []*github.WorkflowRun, deployment *github.Deployment) *github.WorkflowRun {
+	created := deployment.GetCreatedAt().Time
+	for _, run := range runs {
+		if run.GetHeadSHA() != deployment.GetSHA() {
+			continue
+		}
+		if created.Before(run.GetCreatedAt().Time) || created.After(run.GetUpdatedAt().Time) {
+			continue
+		}
+		return run
+	}
+	return nil
+}
+
+// reviewWaitTime returns the time between the reviewed deployment creation and
+// its first status set after the review, 0 if the deployment is still waiting.
+// Deployments are created as soon as the job starts waiting for a review, any
+// waiting statuses are set before the review and skipped
+func reviewWaitTime(deployment *github.Deployment, statuses []*github.DeploymentStatus) float64 {
+	for _, status := range statuses {
+		if status.GetState() == "waiting" {
+			continue
+		}
+		return status.GetCreatedAt().Sub(deployment.GetCreatedAt().Time).Seconds()
+	}
+	return 0
+}
+
+func init() {
+	DeploymentsFlagset.Duration("deployments-window", 7*24*time.Hour, "Time window of deployments to collect metrics from (DEPLOYMENTS_WINDOW)")
+
+	registerCollector("deployments", false, newDeploymentsCollector)
+}
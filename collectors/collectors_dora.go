@@ -0,0 +1,290 @@
+package collectors
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var (
+	DoraFlagset = pflag.NewFlagSet("dora", pflag.ExitOnError)
+
+	// Buckets of lead time for changes, from 1 hour up to 30 days
+	doraLeadTimeBuckets = []float64{
+		1 * 3600, 4 * 3600, 12 * 3600, 24 * 3600, 2 * 24 * 3600,
+		4 * 24 * 3600, 7 * 24 * 3600, 14 * 24 * 3600, 30 * 24 * 3600,
+	}
+	// Buckets of time to restore, from 5 minutes up to 7 days
+	doraTimeToRestoreBuckets = []float64{
+		300, 900, 1800, 3600, 4 * 3600, 12 * 3600, 24 * 3600, 7 * 24 * 3600,
+	}
+)
+
+// doraDeployment represents the outcome of a deployment
+type doraDeployment struct {
+	sha      string
+	ref      string
+	success  bool
+	failure  bool
+	finished time.Time
+}
+
+// doraMerge represents a pull request merged to the default branch
+type doraMerge struct {
+	sha    string
+	merged time.Time
+}
+
+// doraMetrics represents DORA metrics of a single environment
+type doraMetrics struct {
+	successes     int
+	failures      int
+	failureRate   float64
+	leadTime      []float64
+	timeToRestore []float64
+}
+
+type doraCollector struct {
+	deploymentFrequency *metrics.Desc
+	leadTime            *metrics.Desc
+	changeFailureRate   *metrics.Desc
+	timeToRestore       *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newDoraCollector(client *github.Client) (Collector, error) {
+	deploymentFrequency := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "dora", "deployment_frequency_per_day",
+		),
+		"Average successful deployments per day within the configured window",
+		[]string{"org", "repo", "environment"}, nil,
+	)
+	leadTime := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "dora", "lead_time_seconds",
+		),
+		"Time from pull request merge to the default branch to its successful deployment",
+		[]string{"org", "repo", "environment"}, nil,
+	)
+	changeFailureRate := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "dora", "change_failure_rate",
+		),
+		"Ratio of failed deployments to all finished deployments within the configured window",
+		[]string{"org", "repo", "environment"}, nil,
+	)
+	timeToRestore := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "dora", "time_to_restore_seconds",
+		),
+		"Time from a failed deployment to the following successful deployment",
+		[]string{"org", "repo", "environment"}, nil,
+	)
+
+	c := &doraCollector{
+		deploymentFrequency: deploymentFrequency,
+		leadTime:            leadTime,
+		changeFailureRate:   changeFailureRate,
+		timeToRestore:       timeToRestore,
+		client:              client,
+		wg:                  &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *doraCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := getRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string, branch string) {
+				c.scrapeDora(ctx, ch, errCh, org, repo, branch)
+				c.wg.Done()
+			}(org, repo.GetName(), repo.GetDefaultBranch())
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *doraCollector) scrapeDora(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string, branch string) {
+	window := viper.GetDuration("dora-window")
+	since := time.Now().Add(-window)
+
+	deployments, err := listDeployments(ctx, c.client, org, repo, since)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	if len(deployments) == 0 {
+		return
+	}
+
+	environments := make(map[string][]*doraDeployment)
+	for _, deployment := range deployments {
+		statuses, err := listDeploymentStatuses(ctx, c.client, org, repo, deployment.GetID())
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		if len(statuses) == 0 {
+			continue
+		}
+
+		d := &doraDeployment{
+			sha: deployment.GetSHA(),
+			ref: deployment.GetRef(),
+		}
+		if success := findDeploymentStatus(statuses, "success"); success != nil {
+			d.success = true
+			d.finished = success.GetCreatedAt().Time
+		} else if last := statuses[len(statuses)-1]; last.GetState() == "failure" || last.GetState() == "error" {
+			d.failure = true
+			d.finished = last.GetCreatedAt().Time
+		} else {
+			continue
+		}
+		environments[deployment.GetEnvironment()] = append(environments[deployment.GetEnvironment()], d)
+	}
+
+	pulls, err := listPullRequests(ctx, c.client, org, repo, "closed", since)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	merges := make([]*doraMerge, 0)
+	for _, pull := range pulls {
+		if pull.MergedAt == nil || pull.GetMergedAt().Before(since) || pull.GetBase().GetRef() != branch {
+			continue
+		}
+		merges = append(merges, &doraMerge{sha: pull.GetMergeCommitSHA(), merged: pull.GetMergedAt()})
+	}
+
+	// Deployments of the default branch may ship several merge commits at
+	// once, which is checked by comparing commits. Comparisons never change,
+	// so they are shared between environments
+	compared := make(map[[2]string]bool)
+	contains := func(d *doraDeployment, sha string) (bool, error) {
+		if d.ref != branch {
+			return false, nil
+		}
+		key := [2]string{sha, d.sha}
+		if x, ok := compared[key]; ok {
+			return x, nil
+		}
+		comparison, _, err := c.client.Repositories.CompareCommits(ctx, org, repo, sha, d.sha, &github.ListOptions{PerPage: 1})
+		if isFeatureUnavailable(err) {
+			// Commits may no longer exist after force pushes
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		compared[key] = comparison.GetStatus() == "identical" || comparison.GetStatus() == "ahead"
+		return compared[key], nil
+	}
+
+	for environment, deployments := range environments {
+		m, err := computeDora(deployments, merges, contains)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+
+		ch <- metrics.MustNewConstMetric(
+			c.deploymentFrequency, metrics.GaugeValue, float64(m.successes)/window.Hours()*24,
+			org, repo, environment,
+		)
+		ch <- metrics.MustNewConstMetric(
+			c.changeFailureRate, metrics.GaugeValue, m.failureRate,
+			org, repo, environment,
+		)
+		ch <- newConstHistogram(c.leadTime, doraLeadTimeBuckets, m.leadTime, org, repo, environment)
+		ch <- newConstHistogram(c.timeToRestore, doraTimeToRestoreBuckets, m.timeToRestore, org, repo, environment)
+	}
+}
+
+// computeDora computes DORA metrics of the environment deployments. Lead time
+// of each merge is measured up to the first successful deployment of its merge
+// commit, either deployed directly or reported as contained by contains
+func computeDora(deployments []*doraDeployment, merges []*doraMerge, contains func(d *doraDeployment, sha string) (bool, error)) (*doraMetrics, error) {
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].finished.Before(deployments[j].finished)
+	})
+
+	m := &doraMetrics{
+		leadTime:      make([]float64, 0),
+		timeToRestore: make([]float64, 0),
+	}
+
+	var failed time.Time
+	for _, d := range deployments {
+		if d.failure {
+			m.failures++
+			if failed.IsZero() {
+				failed = d.finished
+			}
+			continue
+		}
+		m.successes++
+		if !failed.IsZero() {
+			m.timeToRestore = append(m.timeToRestore, d.finished.Sub(failed).Seconds())
+			failed = time.Time{}
+		}
+	}
+
+	for _, merge := range merges {
+		for _, d := range deployments {
+			if !d.success || d.finished.Before(merge.merged) {
+				continue
+			}
+			shipped := d.sha == merge.sha
+			if !shipped {
+				var err error
+				shipped, err = contains(d, merge.sha)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if shipped {
+				m.leadTime = append(m.leadTime, d.finished.Sub(merge.merged).Seconds())
+				break
+			}
+		}
+	}
+
+	if m.successes+m.failures > 0 {
+		m.failureRate = float64(m.failures) / float64(m.successes+m.failures)
+	}
+
+	return m, nil
+}
+
+func init() {
+	DoraFlagset.Duration("dora-window", 30*24*time.Hour, "Time window of deployments and pull requests to collect DORA metrics from (DORA_WINDOW)")
+
+	registerCollector("dora", false, newDoraCollector)
+}
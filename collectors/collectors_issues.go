@@ -0,0 +1,210 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var (
+	IssuesFlagset = pflag.NewFlagSet("issues", pflag.ExitOnError)
+
+	// Buckets of open issues age, from 1 day up to 2 years
+	issuesAgeBuckets = []float64{
+		1 * 24 * 3600, 7 * 24 * 3600, 30 * 24 * 3600, 90 * 24 * 3600,
+		180 * 24 * 3600, 365 * 24 * 3600, 730 * 24 * 3600,
+	}
+	// Buckets of issues close time, from 1 hour up to 90 days
+	issuesCloseTimeBuckets = []float64{
+		1 * 3600, 4 * 3600, 24 * 3600, 7 * 24 * 3600,
+		14 * 24 * 3600, 30 * 24 * 3600, 90 * 24 * 3600,
+	}
+)
+
+type issuesKey struct {
+	label    string
+	assigned string
+}
+
+type issuesCollector struct {
+	issuesCount     *metrics.Desc
+	issuesAge       *metrics.Desc
+	issuesCloseTime *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newIssuesCollector(client *github.Client) (Collector, error) {
+	issuesCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "issues", "count",
+		),
+		"Total open issues and issues closed within the configured window",
+		[]string{"org", "repo", "state", "label", "assigned"}, nil,
+	)
+	issuesAge := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "issues", "open_age_seconds",
+		),
+		"Age of open issues",
+		[]string{"org", "repo"}, nil,
+	)
+	issuesCloseTime := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "issues", "close_time_seconds",
+		),
+		"Time from issue creation to close of issues closed within the configured window",
+		[]string{"org", "repo"}, nil,
+	)
+
+	c := &issuesCollector{
+		issuesCount:     issuesCount,
+		issuesAge:       issuesAge,
+		issuesCloseTime: issuesCloseTime,
+		client:          client,
+		wg:              &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *issuesCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapeIssues(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *issuesCollector) scrapeIssues(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	now := time.Now()
+	cutoff := now.Add(-viper.GetDuration("issues-window"))
+
+	open, err := c.listIssues(ctx, org, repo, "open", time.Time{})
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	closed, err := c.listIssues(ctx, org, repo, "closed", cutoff)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	openCounts := make(map[issuesKey]int)
+	openAge := make([]float64, 0)
+	for _, issue := range open {
+		countIssue(openCounts, issue)
+		openAge = append(openAge, now.Sub(issue.GetCreatedAt()).Seconds())
+	}
+
+	closedCounts := make(map[issuesKey]int)
+	closeTime := make([]float64, 0)
+	for _, issue := range closed {
+		if issue.ClosedAt == nil || issue.GetClosedAt().Before(cutoff) {
+			continue
+		}
+		countIssue(closedCounts, issue)
+		closeTime = append(closeTime, issue.GetClosedAt().Sub(issue.GetCreatedAt()).Seconds())
+	}
+
+	for key, count := range openCounts {
+		ch <- metrics.MustNewConstMetric(
+			c.issuesCount, metrics.GaugeValue, float64(count),
+			org, repo, "open", key.label, key.assigned,
+		)
+	}
+	for key, count := range closedCounts {
+		ch <- metrics.MustNewConstMetric(
+			c.issuesCount, metrics.GaugeValue, float64(count),
+			org, repo, "closed", key.label, key.assigned,
+		)
+	}
+	ch <- newConstHistogram(c.issuesAge, issuesAgeBuckets, openAge, org, repo)
+	ch <- newConstHistogram(c.issuesCloseTime, issuesCloseTimeBuckets, closeTime, org, repo)
+}
+
+// listIssues lists issues of the given state, excluding pull requests. If
+// since is set, issues are listed from the most recently updated until the
+// first one updated before since
+func (c *issuesCollector) listIssues(ctx context.Context, org string, repo string, state string, since time.Time) ([]*github.Issue, error) {
+	issues := make([]*github.Issue, 0)
+	opts := &github.IssueListByRepoOptions{
+		State: state,
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	if !since.IsZero() {
+		opts.Sort = "updated"
+		opts.Direction = "desc"
+	}
+	for {
+		results, resp, err := c.client.Issues.ListByRepo(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range results {
+			if !since.IsZero() && issue.GetUpdatedAt().Before(since) {
+				return issues, nil
+			}
+			if issue.IsPullRequest() {
+				continue
+			}
+			issues = append(issues, issue)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return issues, nil
+}
+
+// countIssue increments the counts of each issue label, or of an empty label
+// if the issue has no labels
+func countIssue(counts map[issuesKey]int, issue *github.Issue) {
+	key := issuesKey{
+		assigned: strconv.FormatBool(len(issue.Assignees) > 0 || issue.Assignee != nil),
+	}
+	if len(issue.Labels) == 0 {
+		counts[key]++
+	}
+	for _, label := range issue.Labels {
+		key.label = label.GetName()
+		counts[key]++
+	}
+}
+
+func init() {
+	IssuesFlagset.Duration("issues-window", 7*24*time.Hour, "Time window of closed issues to collect metrics from (ISSUES_WINDOW)")
+
+	registerCollector("issues", false, newIssuesCollector)
+}
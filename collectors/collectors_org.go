@@ -0,0 +1,149 @@
+package collectors
+
+import (
+	"context"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+type orgCollector struct {
+	membersCount              *metrics.Desc
+	membersWithout2FACount    *metrics.Desc
+	teamsCount                *metrics.Desc
+	teamMembersCount          *metrics.Desc
+	outsideCollaboratorsCount *metrics.Desc
+	pendingInvitationsCount   *metrics.Desc
+
+	client *github.Client
+}
+
+func newOrgCollector(client *github.Client) (Collector, error) {
+	c := &orgCollector{
+		membersCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "org", "members_count"),
+			"Total organization members per role", []string{"org", "role"}, nil,
+		),
+		membersWithout2FACount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "org", "members_without_2fa_count"),
+			"Total organization members without two-factor authentication enabled", []string{"org"}, nil,
+		),
+		teamsCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "org", "teams_count"),
+			"Total organization teams", []string{"org"}, nil,
+		),
+		teamMembersCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "org", "team_members_count"),
+			"Total members per organization team", []string{"org", "team"}, nil,
+		),
+		outsideCollaboratorsCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "org", "outside_collaborators_count"),
+			"Total outside collaborators of organization repositories", []string{"org"}, nil,
+		),
+		pendingInvitationsCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "org", "pending_invitations_count"),
+			"Total pending organization invitations", []string{"org"}, nil,
+		),
+		client: client,
+	}
+
+	return c, nil
+}
+
+func (c *orgCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		for _, role := range []string{"admin", "member"} {
+			count, err := countAll(func(opts github.ListOptions) (int, *github.Response, error) {
+				results, resp, err := c.client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{Role: role, ListOptions: opts})
+				return len(results), resp, err
+			})
+			if err != nil {
+				return err
+			}
+			ch <- metrics.MustNewConstMetric(c.membersCount, metrics.GaugeValue, float64(count), org, role)
+		}
+
+		count, err := countAll(func(opts github.ListOptions) (int, *github.Response, error) {
+			results, resp, err := c.client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{Filter: "2fa_disabled", ListOptions: opts})
+			return len(results), resp, err
+		})
+		if err != nil {
+			return err
+		}
+		ch <- metrics.MustNewConstMetric(c.membersWithout2FACount, metrics.GaugeValue, float64(count), org)
+
+		count, err = countAll(func(opts github.ListOptions) (int, *github.Response, error) {
+			results, resp, err := c.client.Organizations.ListOutsideCollaborators(ctx, org, &github.ListOutsideCollaboratorsOptions{ListOptions: opts})
+			return len(results), resp, err
+		})
+		if err != nil {
+			return err
+		}
+		ch <- metrics.MustNewConstMetric(c.outsideCollaboratorsCount, metrics.GaugeValue, float64(count), org)
+
+		count, err = countAll(func(opts github.ListOptions) (int, *github.Response, error) {
+			results, resp, err := c.client.Organizations.ListPendingOrgInvitations(ctx, org, &opts)
+			return len(results), resp, err
+		})
+		if err != nil {
+			return err
+		}
+		ch <- metrics.MustNewConstMetric(c.pendingInvitationsCount, metrics.GaugeValue, float64(count), org)
+
+		teams := make([]*github.Team, 0)
+		opts := &github.ListOptions{
+			PerPage: 100,
+		}
+		for {
+			results, resp, err := c.client.Teams.ListTeams(ctx, org, opts)
+			if err != nil {
+				return err
+			}
+			teams = append(teams, results...)
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+		ch <- metrics.MustNewConstMetric(c.teamsCount, metrics.GaugeValue, float64(len(teams)), org)
+
+		for _, team := range teams {
+			count, err := countAll(func(opts github.ListOptions) (int, *github.Response, error) {
+				results, resp, err := c.client.Teams.ListTeamMembersBySlug(ctx, org, team.GetSlug(), &github.TeamListTeamMembersOptions{ListOptions: opts})
+				return len(results), resp, err
+			})
+			if err != nil {
+				return err
+			}
+			ch <- metrics.MustNewConstMetric(c.teamMembersCount, metrics.GaugeValue, float64(count), org, team.GetSlug())
+		}
+	}
+
+	return nil
+}
+
+// countAll pages through all results of the list function and returns the
+// total count of them
+func countAll(list func(opts github.ListOptions) (int, *github.Response, error)) (int, error) {
+	total := 0
+	opts := github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		count, resp, err := list(opts)
+		if err != nil {
+			return 0, err
+		}
+		total += count
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return total, nil
+}
+
+func init() {
+	registerCollector("org", false, newOrgCollector)
+}
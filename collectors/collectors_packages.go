@@ -0,0 +1,82 @@
+package collectors
+
+import (
+	"context"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+// Package types supported by the packages API. Download counts are not
+// exposed by the API, so they are not collected
+var packageTypes = []string{"npm", "maven", "rubygems", "docker", "nuget", "container"}
+
+type packagesKey struct {
+	packageType string
+	visibility  string
+}
+
+type packagesCollector struct {
+	packagesCount *metrics.Desc
+	versionsCount *metrics.Desc
+
+	client *github.Client
+}
+
+func newPackagesCollector(client *github.Client) (Collector, error) {
+	c := &packagesCollector{
+		packagesCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "packages", "count"),
+			"Total organization packages per type and visibility", []string{"org", "type", "visibility"}, nil,
+		),
+		versionsCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "packages", "versions_count"),
+			"Total versions per organization package", []string{"org", "type", "package", "repo"}, nil,
+		),
+		client: client,
+	}
+
+	return c, nil
+}
+
+func (c *packagesCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		counts := make(map[packagesKey]int)
+		for _, packageType := range packageTypes {
+			opts := &github.PackageListOptions{
+				PackageType: github.String(packageType),
+				ListOptions: github.ListOptions{
+					PerPage: 100,
+				},
+			}
+			for {
+				results, resp, err := c.client.Organizations.ListPackages(ctx, org, opts)
+				if err != nil {
+					return err
+				}
+				for _, pkg := range results {
+					counts[packagesKey{packageType: packageType, visibility: pkg.GetVisibility()}]++
+					ch <- metrics.MustNewConstMetric(
+						c.versionsCount, metrics.GaugeValue, float64(pkg.GetVersionCount()),
+						org, packageType, pkg.GetName(), pkg.GetRepository().GetName(),
+					)
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+		}
+
+		for key, count := range counts {
+			ch <- metrics.MustNewConstMetric(c.packagesCount, metrics.GaugeValue, float64(count), org, key.packageType, key.visibility)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	registerCollector("packages", false, newPackagesCollector)
+}
@@ -0,0 +1,251 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var (
+	PullsFlagset = pflag.NewFlagSet("pulls", pflag.ExitOnError)
+
+	// Buckets of pull request latencies, from 1 hour up to 30 days
+	pullsDurationBuckets = []float64{
+		1 * 3600, 4 * 3600, 12 * 3600, 24 * 3600, 2 * 24 * 3600,
+		4 * 24 * 3600, 7 * 24 * 3600, 14 * 24 * 3600, 30 * 24 * 3600,
+	}
+)
+
+type pullsOpenKey struct {
+	draft string
+	base  string
+	label string
+}
+
+type pullsCollector struct {
+	openCount         *metrics.Desc
+	mergedCount       *metrics.Desc
+	timeToFirstReview *metrics.Desc
+	timeToMerge       *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newPullsCollector(client *github.Client) (Collector, error) {
+	openCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "pulls", "open_count",
+		),
+		"Total open pull requests",
+		[]string{"org", "repo", "draft", "base", "label"}, nil,
+	)
+	mergedCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "pulls", "merged_count",
+		),
+		"Total pull requests merged within the configured window",
+		[]string{"org", "repo", "base"}, nil,
+	)
+	timeToFirstReview := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "pulls", "time_to_first_review_seconds",
+		),
+		"Time from pull request creation to its first review",
+		[]string{"org", "repo"}, nil,
+	)
+	timeToMerge := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "pulls", "time_to_merge_seconds",
+		),
+		"Time from pull request creation to merge",
+		[]string{"org", "repo"}, nil,
+	)
+
+	c := &pullsCollector{
+		openCount:         openCount,
+		mergedCount:       mergedCount,
+		timeToFirstReview: timeToFirstReview,
+		timeToMerge:       timeToMerge,
+		client:            client,
+		wg:                &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *pullsCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapePullRequests(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *pullsCollector) scrapePullRequests(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	cutoff := time.Now().Add(-viper.GetDuration("pulls-window"))
+
+	open, err := listPullRequests(ctx, c.client, org, repo, "open", time.Time{})
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	closed, err := listPullRequests(ctx, c.client, org, repo, "closed", cutoff)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	reviewed := make([]*github.PullRequest, 0)
+
+	openCounts := make(map[pullsOpenKey]int)
+	for _, pull := range open {
+		key := pullsOpenKey{
+			draft: strconv.FormatBool(pull.GetDraft()),
+			base:  pull.GetBase().GetRef(),
+		}
+		if len(pull.Labels) == 0 {
+			openCounts[key]++
+		}
+		for _, label := range pull.Labels {
+			key.label = label.GetName()
+			openCounts[key]++
+		}
+		if pull.GetCreatedAt().After(cutoff) {
+			reviewed = append(reviewed, pull)
+		}
+	}
+
+	mergedCounts := make(map[string]int)
+	timeToMerge := make([]float64, 0)
+	for _, pull := range closed {
+		if pull.MergedAt == nil || pull.GetMergedAt().Before(cutoff) {
+			continue
+		}
+		mergedCounts[pull.GetBase().GetRef()]++
+		timeToMerge = append(timeToMerge, pull.GetMergedAt().Sub(pull.GetCreatedAt()).Seconds())
+		if pull.GetCreatedAt().After(cutoff) {
+			reviewed = append(reviewed, pull)
+		}
+	}
+
+	timeToFirstReview := make([]float64, 0)
+	for _, pull := range reviewed {
+		submitted, err := c.getFirstReviewTime(ctx, org, repo, pull)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		if submitted.IsZero() {
+			continue
+		}
+		timeToFirstReview = append(timeToFirstReview, submitted.Sub(pull.GetCreatedAt()).Seconds())
+	}
+
+	for key, count := range openCounts {
+		ch <- metrics.MustNewConstMetric(
+			c.openCount, metrics.GaugeValue, float64(count),
+			org, repo, key.draft, key.base, key.label,
+		)
+	}
+	for base, count := range mergedCounts {
+		ch <- metrics.MustNewConstMetric(
+			c.mergedCount, metrics.GaugeValue, float64(count),
+			org, repo, base,
+		)
+	}
+	ch <- newConstHistogram(c.timeToMerge, pullsDurationBuckets, timeToMerge, org, repo)
+	ch <- newConstHistogram(c.timeToFirstReview, pullsDurationBuckets, timeToFirstReview, org, repo)
+}
+
+// listPullRequests lists pull requests of the given state. If since is set,
+// pull requests are listed from the most recently updated until the first
+// one updated before since
+func listPullRequests(ctx context.Context, client *github.Client, org string, repo string, state string, since time.Time) ([]*github.PullRequest, error) {
+	pulls := make([]*github.PullRequest, 0)
+	opts := &github.PullRequestListOptions{
+		State: state,
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	if !since.IsZero() {
+		opts.Sort = "updated"
+		opts.Direction = "desc"
+	}
+	for {
+		results, resp, err := client.PullRequests.List(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, pull := range results {
+			if !since.IsZero() && pull.GetUpdatedAt().Before(since) {
+				return pulls, nil
+			}
+			pulls = append(pulls, pull)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return pulls, nil
+}
+
+// getFirstReviewTime returns the submission time of the first review left by
+// someone else than the pull request author. Zero time is returned if the
+// pull request has not been reviewed yet
+func (c *pullsCollector) getFirstReviewTime(ctx context.Context, org string, repo string, pull *github.PullRequest) (time.Time, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		results, resp, err := c.client.PullRequests.ListReviews(ctx, org, repo, pull.GetNumber(), opts)
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, review := range results {
+			if review.SubmittedAt == nil || review.GetUser().GetLogin() == pull.GetUser().GetLogin() {
+				continue
+			}
+			return review.GetSubmittedAt(), nil
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return time.Time{}, nil
+}
+
+func init() {
+	PullsFlagset.Duration("pulls-window", 7*24*time.Hour, "Time window of pull requests to collect merge and review metrics from (PULLS_WINDOW)")
+
+	registerCollector("pulls", false, newPullsCollector)
+}
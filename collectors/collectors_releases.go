@@ -0,0 +1,149 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+type releasesCollector struct {
+	releasesCount   *metrics.Desc
+	latestInfo      *metrics.Desc
+	latestPublished *metrics.Desc
+	assetDownloads  *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newReleasesCollector(client *github.Client) (Collector, error) {
+	releasesCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "releases", "count",
+		),
+		"Total published releases",
+		[]string{"org", "repo", "prerelease"}, nil,
+	)
+	latestInfo := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "releases", "latest_info",
+		),
+		"Information about the latest published release",
+		[]string{"org", "repo", "tag", "name", "prerelease"}, nil,
+	)
+	latestPublished := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "releases", "latest_published_timestamp_seconds",
+		),
+		"Time of the latest published release",
+		[]string{"org", "repo", "tag"}, nil,
+	)
+	assetDownloads := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "releases", "asset_downloads_total",
+		),
+		"Total downloads of release assets",
+		[]string{"org", "repo", "tag", "asset"}, nil,
+	)
+
+	c := &releasesCollector{
+		releasesCount:   releasesCount,
+		latestInfo:      latestInfo,
+		latestPublished: latestPublished,
+		assetDownloads:  assetDownloads,
+		client:          client,
+		wg:              &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *releasesCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapeReleases(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *releasesCollector) scrapeReleases(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	releases := make([]*github.RepositoryRelease, 0)
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		results, resp, err := c.client.Repositories.ListReleases(ctx, org, repo, opts)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		releases = append(releases, results...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var latest *github.RepositoryRelease
+	counts := map[bool]int{false: 0, true: 0}
+	for _, release := range releases {
+		if release.GetDraft() {
+			continue
+		}
+		counts[release.GetPrerelease()]++
+		if latest == nil || release.GetPublishedAt().After(latest.GetPublishedAt().Time) {
+			latest = release
+		}
+		for _, asset := range release.Assets {
+			ch <- metrics.MustNewConstMetric(
+				c.assetDownloads, metrics.CounterValue, float64(asset.GetDownloadCount()),
+				org, repo, release.GetTagName(), asset.GetName(),
+			)
+		}
+	}
+
+	for prerelease, count := range counts {
+		ch <- metrics.MustNewConstMetric(
+			c.releasesCount, metrics.GaugeValue, float64(count),
+			org, repo, strconv.FormatBool(prerelease),
+		)
+	}
+	if latest != nil {
+		ch <- metrics.MustNewConstMetric(
+			c.latestInfo, metrics.GaugeValue, 1,
+			org, repo, latest.GetTagName(), latest.GetName(), strconv.FormatBool(latest.GetPrerelease()),
+		)
+		ch <- metrics.MustNewConstMetric(
+			c.latestPublished, metrics.GaugeValue, float64(latest.GetPublishedAt().Unix()),
+			org, repo, latest.GetTagName(),
+		)
+	}
+}
+
+func init() {
+	registerCollector("releases", false, newReleasesCollector)
+}
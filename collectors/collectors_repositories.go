@@ -0,0 +1,118 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+type repositoriesCollector struct {
+	stargazersCount *metrics.Desc
+	forksCount      *metrics.Desc
+	watchersCount   *metrics.Desc
+	openIssuesCount *metrics.Desc
+	size            *metrics.Desc
+	pushedAt        *metrics.Desc
+	updatedAt       *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newRepositoriesCollector(client *github.Client) (Collector, error) {
+	labels := []string{"org", "repo", "archived", "visibility", "fork", "default_branch"}
+
+	c := &repositoriesCollector{
+		stargazersCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "repositories", "stargazers_count"),
+			"Total stargazers of the repository", labels, nil,
+		),
+		forksCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "repositories", "forks_count"),
+			"Total forks of the repository", labels, nil,
+		),
+		watchersCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "repositories", "watchers_count"),
+			"Total users watching the repository", labels, nil,
+		),
+		openIssuesCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "repositories", "open_issues_count"),
+			"Total open issues and pull requests of the repository", labels, nil,
+		),
+		size: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "repositories", "size_kilobytes"),
+			"Size of the repository", labels, nil,
+		),
+		pushedAt: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "repositories", "pushed_at_timestamp_seconds"),
+			"Time of the last push to the repository", labels, nil,
+		),
+		updatedAt: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "repositories", "updated_at_timestamp_seconds"),
+			"Time of the last update of the repository", labels, nil,
+		),
+		client: client,
+		wg:     &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *repositoriesCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapeRepository(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *repositoriesCollector) scrapeRepository(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	// Watchers are only returned as subscribers count of a single repository,
+	// watchers count of listed repositories is an alias of stargazers count
+	r, _, err := c.client.Repositories.Get(ctx, org, repo)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	labels := []string{
+		org, r.GetName(), strconv.FormatBool(r.GetArchived()), r.GetVisibility(),
+		strconv.FormatBool(r.GetFork()), r.GetDefaultBranch(),
+	}
+
+	ch <- metrics.MustNewConstMetric(c.stargazersCount, metrics.GaugeValue, float64(r.GetStargazersCount()), labels...)
+	ch <- metrics.MustNewConstMetric(c.forksCount, metrics.GaugeValue, float64(r.GetForksCount()), labels...)
+	ch <- metrics.MustNewConstMetric(c.watchersCount, metrics.GaugeValue, float64(r.GetSubscribersCount()), labels...)
+	ch <- metrics.MustNewConstMetric(c.openIssuesCount, metrics.GaugeValue, float64(r.GetOpenIssuesCount()), labels...)
+	ch <- metrics.MustNewConstMetric(c.size, metrics.GaugeValue, float64(r.GetSize()), labels...)
+	ch <- metrics.MustNewConstMetric(c.pushedAt, metrics.GaugeValue, float64(r.GetPushedAt().Unix()), labels...)
+	ch <- metrics.MustNewConstMetric(c.updatedAt, metrics.GaugeValue, float64(r.GetUpdatedAt().Unix()), labels...)
+}
+
+func init() {
+	registerCollector("repositories", false, newRepositoriesCollector)
+}
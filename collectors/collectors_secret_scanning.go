@@ -0,0 +1,156 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+var (
+	// Buckets of secret scanning alerts resolution time, from 1 hour up to 30 days
+	secretScanningResolutionBuckets = []float64{
+		1 * 3600, 4 * 3600, 12 * 3600, 24 * 3600,
+		3 * 24 * 3600, 7 * 24 * 3600, 30 * 24 * 3600,
+	}
+)
+
+// secretScanningAlert represents a secret scanning alert of a repository
+type secretScanningAlert struct {
+	Number     int        `json:"number"`
+	State      string     `json:"state"`
+	Resolution string     `json:"resolution"`
+	SecretType string     `json:"secret_type"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+}
+
+type secretScanningKey struct {
+	secretType string
+	state      string
+}
+
+type secretScanningCollector struct {
+	alertsCount    *metrics.Desc
+	resolutionTime *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newSecretScanningCollector(client *github.Client) (Collector, error) {
+	alertsCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "secret_scanning", "alerts_count",
+		),
+		"Total secret scanning alerts",
+		[]string{"org", "repo", "secret_type", "state"}, nil,
+	)
+	resolutionTime := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "secret_scanning", "resolution_time_seconds",
+		),
+		"Time from secret scanning alert creation to its resolution",
+		[]string{"org", "repo"}, nil,
+	)
+
+	c := &secretScanningCollector{
+		alertsCount:    alertsCount,
+		resolutionTime: resolutionTime,
+		client:         client,
+		wg:             &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *secretScanningCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapeAlerts(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+// scrapeAlerts collects secret scanning alerts of the repository. Repositories
+// without secret scanning enabled are skipped
+func (c *secretScanningCollector) scrapeAlerts(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	alerts := make([]*secretScanningAlert, 0)
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		u, err := addListOptions(fmt.Sprintf("repos/%v/%v/secret-scanning/alerts", org, repo), opts)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		req, err := c.client.NewRequest("GET", u, nil)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		results := make([]*secretScanningAlert, 0)
+		resp, err := c.client.Do(ctx, req, &results)
+		if isFeatureUnavailable(err) {
+			return
+		}
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		alerts = append(alerts, results...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	counts := make(map[secretScanningKey]int)
+	resolutionTime := make([]float64, 0)
+	for _, alert := range alerts {
+		counts[secretScanningKey{
+			secretType: alert.SecretType,
+			state:      alert.State,
+		}]++
+		if alert.State == "resolved" && alert.ResolvedAt != nil {
+			resolutionTime = append(resolutionTime, alert.ResolvedAt.Sub(alert.CreatedAt).Seconds())
+		}
+	}
+
+	for key, count := range counts {
+		ch <- metrics.MustNewConstMetric(
+			c.alertsCount, metrics.GaugeValue, float64(count),
+			org, repo, key.secretType, key.state,
+		)
+	}
+	ch <- newConstHistogram(c.resolutionTime, secretScanningResolutionBuckets, resolutionTime, org, repo)
+}
+
+func init() {
+	registerCollector("secret_scanning", false, newSecretScanningCollector)
+}
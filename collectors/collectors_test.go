@@ -0,0 +1,247 @@
+package collectors
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestNewConstHistogram(t *testing.T) {
+	desc := metrics.NewDesc("foobar", "foobar", []string{"foo"}, nil)
+
+	m := &dto.Metric{}
+	err := newConstHistogram(desc, []float64{1, 5, 10}, []float64{0.5, 3, 5, 20}, "bar").Write(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	h := m.GetHistogram()
+	if h.GetSampleCount() != 4 {
+		t.Errorf("count : want == %v got == %v", 4, h.GetSampleCount())
+	}
+	if h.GetSampleSum() != 28.5 {
+		t.Errorf("sum : want == %v got == %v", 28.5, h.GetSampleSum())
+	}
+
+	tests := []struct {
+		bound float64
+		count uint64
+	}{
+		{1, 1},
+		{5, 3},
+		{10, 3},
+	}
+
+	for i, test := range tests {
+		b := h.GetBucket()[i]
+		if b.GetUpperBound() != test.bound || b.GetCumulativeCount() != test.count {
+			t.Errorf("bucket %v : want == %v got == %v", test.bound, test.count, b.GetCumulativeCount())
+		}
+	}
+}
+
+func TestAddListOptions(t *testing.T) {
+	tests := []struct {
+		url  string
+		opts *github.ListOptions
+		want string
+	}{
+		{"orgs/foo/bar", &github.ListOptions{}, "orgs/foo/bar"},
+		{"orgs/foo/bar", &github.ListOptions{PerPage: 100}, "orgs/foo/bar?per_page=100"},
+		{"orgs/foo/bar?state=open", &github.ListOptions{PerPage: 100, Page: 2}, "orgs/foo/bar?page=2&per_page=100&state=open"},
+	}
+
+	for _, test := range tests {
+		u, err := addListOptions(test.url, test.opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if u != test.want {
+			t.Errorf("url %s : want == %v got == %v", test.url, test.want, u)
+		}
+	}
+}
+
+func TestAddListCursorOptions(t *testing.T) {
+	tests := []struct {
+		url  string
+		opts *github.ListCursorOptions
+		want string
+	}{
+		{"orgs/foo/bar", &github.ListCursorOptions{}, "orgs/foo/bar"},
+		{"orgs/foo/bar", &github.ListCursorOptions{PerPage: 100}, "orgs/foo/bar?per_page=100"},
+		{"orgs/foo/bar?state=open", &github.ListCursorOptions{PerPage: 100, After: "Y3Vyc29y"}, "orgs/foo/bar?after=Y3Vyc29y&per_page=100&state=open"},
+	}
+
+	for _, test := range tests {
+		u, err := addListCursorOptions(test.url, test.opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if u != test.want {
+			t.Errorf("url %s : want == %v got == %v", test.url, test.want, u)
+		}
+	}
+}
+
+func TestIsFeatureUnavailable(t *testing.T) {
+	tests := []struct {
+		err         error
+		unavailable bool
+	}{
+		{nil, false},
+		{errors.New("foobar"), false},
+		{&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}, true},
+		{&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}}, true},
+		{&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusInternalServerError}}, false},
+	}
+
+	for _, test := range tests {
+		x := isFeatureUnavailable(test.err)
+		if x != test.unavailable {
+			t.Errorf("error %v : want == %v got == %v", test.err, test.unavailable, x)
+		}
+	}
+}
+
+func TestIsPlanUnsupported(t *testing.T) {
+	tests := []struct {
+		err         error
+		unsupported bool
+	}{
+		{nil, false},
+		{errors.New("foobar"), false},
+		{&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}, Message: "Branch not found"}, false},
+		{&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}, Message: "Resource not accessible by integration"}, false},
+		{&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}, Message: "Upgrade to GitHub Pro or make this repository public to enable this feature."}, true},
+	}
+
+	for _, test := range tests {
+		x := isPlanUnsupported(test.err)
+		if x != test.unsupported {
+			t.Errorf("error %v : want == %v got == %v", test.err, test.unsupported, x)
+		}
+	}
+}
+
+func TestReviewWaitTime(t *testing.T) {
+	created := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	status := func(state string, after time.Duration) *github.DeploymentStatus {
+		return &github.DeploymentStatus{
+			State:     github.String(state),
+			CreatedAt: &github.Timestamp{Time: created.Add(after)},
+		}
+	}
+
+	tests := []struct {
+		statuses []*github.DeploymentStatus
+		wait     float64
+	}{
+		{[]*github.DeploymentStatus{}, 0},
+		{[]*github.DeploymentStatus{status("waiting", 0)}, 0},
+		{[]*github.DeploymentStatus{status("queued", 10*time.Minute), status("success", 15*time.Minute)}, 600},
+		{[]*github.DeploymentStatus{status("waiting", 0), status("in_progress", time.Hour)}, 3600},
+	}
+
+	deployment := &github.Deployment{CreatedAt: &github.Timestamp{Time: created}}
+	for i, test := range tests {
+		x := reviewWaitTime(deployment, test.statuses)
+		if x != test.wait {
+			t.Errorf("test %v : want == %v got == %v", i, test.wait, x)
+		}
+	}
+}
+
+func TestFindDeploymentWorkflowRun(t *testing.T) {
+	created := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	runs := []*github.WorkflowRun{
+		{
+			ID:        github.Int64(2),
+			HeadSHA:   github.String("foo"),
+			CreatedAt: &github.Timestamp{Time: created.Add(2 * time.Hour)},
+			UpdatedAt: &github.Timestamp{Time: created.Add(3 * time.Hour)},
+		},
+		{
+			ID:        github.Int64(1),
+			HeadSHA:   github.String("foo"),
+			CreatedAt: &github.Timestamp{Time: created},
+			UpdatedAt: &github.Timestamp{Time: created.Add(time.Hour)},
+		},
+	}
+
+	tests := []struct {
+		sha     string
+		created time.Time
+		id      int64
+	}{
+		{"foo", created.Add(30 * time.Minute), 1},
+		{"foo", created.Add(150 * time.Minute), 2},
+		{"foo", created.Add(90 * time.Minute), 0},
+		{"bar", created.Add(30 * time.Minute), 0},
+	}
+
+	for _, test := range tests {
+		deployment := &github.Deployment{
+			SHA:       github.String(test.sha),
+			CreatedAt: &github.Timestamp{Time: test.created},
+		}
+		x := findDeploymentWorkflowRun(runs, deployment).GetID()
+		if x != test.id {
+			t.Errorf("deployment %v %v : want == %v got == %v", test.sha, test.created, test.id, x)
+		}
+	}
+}
+
+func TestComputeDora(t *testing.T) {
+	start := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	deployments := []*doraDeployment{
+		{sha: "c", ref: "main", success: true, finished: start.Add(5 * time.Hour)},
+		{sha: "b", ref: "main", failure: true, finished: start.Add(2 * time.Hour)},
+		{sha: "a", ref: "main", success: true, finished: start.Add(time.Hour)},
+		{sha: "x", ref: "feature", success: true, finished: start.Add(90 * time.Minute)},
+		{sha: "b", ref: "main", failure: true, finished: start.Add(3 * time.Hour)},
+	}
+	merges := []*doraMerge{
+		{sha: "a", merged: start},
+		{sha: "m", merged: start.Add(70 * time.Minute)},
+		{sha: "z", merged: start.Add(6 * time.Hour)},
+	}
+	contains := func(d *doraDeployment, sha string) (bool, error) {
+		return d.ref == "main" && d.sha == "c" && sha == "m", nil
+	}
+
+	m, err := computeDora(deployments, merges, contains)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if m.successes != 3 || m.failures != 2 {
+		t.Errorf("deployments : want == %v/%v got == %v/%v", 3, 2, m.successes, m.failures)
+	}
+	if m.failureRate != 0.4 {
+		t.Errorf("failure rate : want == %v got == %v", 0.4, m.failureRate)
+	}
+	if len(m.timeToRestore) != 1 || m.timeToRestore[0] != 3*3600 {
+		t.Errorf("time to restore : want == %v got == %v", []float64{3 * 3600}, m.timeToRestore)
+	}
+	// Merge "m" is deployed by "c", not by the earlier feature branch deployment
+	if len(m.leadTime) != 2 || m.leadTime[0] != 3600 || m.leadTime[1] != 230*60 {
+		t.Errorf("lead time : want == %v got == %v", []float64{3600, 230 * 60}, m.leadTime)
+	}
+
+	h := &dto.Metric{}
+	desc := metrics.NewDesc("foobar", "foobar", nil, nil)
+	err = newConstHistogram(desc, doraLeadTimeBuckets, m.leadTime).Write(h)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buckets := h.GetHistogram().GetBucket()
+	if buckets[0].GetCumulativeCount() != 1 || buckets[1].GetCumulativeCount() != 2 {
+		t.Errorf("lead time buckets : want == %v/%v got == %v/%v", 1, 2, buckets[0].GetCumulativeCount(), buckets[1].GetCumulativeCount())
+	}
+}
@@ -0,0 +1,134 @@
+package collectors
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+type trafficCollector struct {
+	viewsCount       *metrics.Desc
+	viewsUniques     *metrics.Desc
+	clonesCount      *metrics.Desc
+	clonesUniques    *metrics.Desc
+	referrersCount   *metrics.Desc
+	referrersUniques *metrics.Desc
+	pathsCount       *metrics.Desc
+	pathsUniques     *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newTrafficCollector(client *github.Client) (Collector, error) {
+	c := &trafficCollector{
+		viewsCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "traffic", "views_count"),
+			"Total views of the repository in the last 14 days", []string{"org", "repo"}, nil,
+		),
+		viewsUniques: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "traffic", "views_uniques"),
+			"Unique visitors of the repository in the last 14 days", []string{"org", "repo"}, nil,
+		),
+		clonesCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "traffic", "clones_count"),
+			"Total clones of the repository in the last 14 days", []string{"org", "repo"}, nil,
+		),
+		clonesUniques: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "traffic", "clones_uniques"),
+			"Unique cloners of the repository in the last 14 days", []string{"org", "repo"}, nil,
+		),
+		referrersCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "traffic", "referrers_count"),
+			"Total views from the top referrers in the last 14 days", []string{"org", "repo", "referrer"}, nil,
+		),
+		referrersUniques: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "traffic", "referrers_uniques"),
+			"Unique visitors from the top referrers in the last 14 days", []string{"org", "repo", "referrer"}, nil,
+		),
+		pathsCount: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "traffic", "paths_count"),
+			"Total views of the popular paths in the last 14 days", []string{"org", "repo", "path"}, nil,
+		),
+		pathsUniques: metrics.NewDesc(
+			metrics.BuildFQName(defaultNamespace, "traffic", "paths_uniques"),
+			"Unique visitors of the popular paths in the last 14 days", []string{"org", "repo", "path"}, nil,
+		),
+		client: client,
+		wg:     &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *trafficCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapeTraffic(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *trafficCollector) scrapeTraffic(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	views, _, err := c.client.Repositories.ListTrafficViews(ctx, org, repo, nil)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	clones, _, err := c.client.Repositories.ListTrafficClones(ctx, org, repo, nil)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	referrers, _, err := c.client.Repositories.ListTrafficReferrers(ctx, org, repo)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+	paths, _, err := c.client.Repositories.ListTrafficPaths(ctx, org, repo)
+	if err != nil {
+		sendError(errCh, err)
+		return
+	}
+
+	ch <- metrics.MustNewConstMetric(c.viewsCount, metrics.GaugeValue, float64(views.GetCount()), org, repo)
+	ch <- metrics.MustNewConstMetric(c.viewsUniques, metrics.GaugeValue, float64(views.GetUniques()), org, repo)
+	ch <- metrics.MustNewConstMetric(c.clonesCount, metrics.GaugeValue, float64(clones.GetCount()), org, repo)
+	ch <- metrics.MustNewConstMetric(c.clonesUniques, metrics.GaugeValue, float64(clones.GetUniques()), org, repo)
+
+	for _, referrer := range referrers {
+		ch <- metrics.MustNewConstMetric(c.referrersCount, metrics.GaugeValue, float64(referrer.GetCount()), org, repo, referrer.GetReferrer())
+		ch <- metrics.MustNewConstMetric(c.referrersUniques, metrics.GaugeValue, float64(referrer.GetUniques()), org, repo, referrer.GetReferrer())
+	}
+	for _, path := range paths {
+		ch <- metrics.MustNewConstMetric(c.pathsCount, metrics.GaugeValue, float64(path.GetCount()), org, repo, path.GetPath())
+		ch <- metrics.MustNewConstMetric(c.pathsUniques, metrics.GaugeValue, float64(path.GetUniques()), org, repo, path.GetPath())
+	}
+}
+
+func init() {
+	registerCollector("traffic", false, newTrafficCollector)
+}
@@ -0,0 +1,220 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var (
+	WebhooksFlagset = pflag.NewFlagSet("webhooks", pflag.ExitOnError)
+)
+
+type webhooksCollector struct {
+	hookActive       *metrics.Desc
+	lastResponseCode *metrics.Desc
+	deliveriesCount  *metrics.Desc
+
+	client *github.Client
+
+	wg *sync.WaitGroup
+}
+
+func newWebhooksCollector(client *github.Client) (Collector, error) {
+	hookActive := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "webhooks", "active",
+		),
+		"Whether the webhook is active, repo is empty for organization webhooks",
+		[]string{"org", "repo", "id", "name"}, nil,
+	)
+	lastResponseCode := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "webhooks", "last_response_code",
+		),
+		"HTTP status code of the last webhook delivery response",
+		[]string{"org", "repo", "id", "status"}, nil,
+	)
+	deliveriesCount := metrics.NewDesc(
+		metrics.BuildFQName(
+			defaultNamespace, "webhooks", "deliveries_count",
+		),
+		"Total webhook deliveries within the configured window",
+		[]string{"org", "repo", "id", "result"}, nil,
+	)
+
+	c := &webhooksCollector{
+		hookActive:       hookActive,
+		lastResponseCode: lastResponseCode,
+		deliveriesCount:  deliveriesCount,
+		client:           client,
+		wg:               &sync.WaitGroup{},
+	}
+
+	return c, nil
+}
+
+func (c *webhooksCollector) Update(ctx context.Context, ch chan<- metrics.Metric) error {
+	errCh := make(chan error, 1)
+	for _, org := range viper.GetStringSlice("gh-organizations") {
+		c.wg.Add(1)
+		go func(org string) {
+			c.scrapeOrganizationHooks(ctx, ch, errCh, org)
+			c.wg.Done()
+		}(org)
+
+		repos, err := listRepositories(ctx, c.client, org)
+		if err != nil {
+			sendError(errCh, err)
+		}
+
+		for _, repo := range repos {
+			c.wg.Add(1)
+			go func(org string, repo string) {
+				c.scrapeRepositoryHooks(ctx, ch, errCh, org, repo)
+				c.wg.Done()
+			}(org, repo)
+		}
+	}
+
+	c.wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (c *webhooksCollector) scrapeOrganizationHooks(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string) {
+	hooks := make([]*github.Hook, 0)
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		results, resp, err := c.client.Organizations.ListHooks(ctx, org, opts)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		hooks = append(hooks, results...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	for _, hook := range hooks {
+		id := hook.GetID()
+		success, failure, err := countHookDeliveries(func(opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error) {
+			return c.client.Organizations.ListHookDeliveries(ctx, org, id, opts)
+		})
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		c.collectHook(ch, hook, success, failure, org, "")
+	}
+}
+
+func (c *webhooksCollector) scrapeRepositoryHooks(ctx context.Context, ch chan<- metrics.Metric, errCh chan<- error, org string, repo string) {
+	hooks := make([]*github.Hook, 0)
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		results, resp, err := c.client.Repositories.ListHooks(ctx, org, repo, opts)
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		hooks = append(hooks, results...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	for _, hook := range hooks {
+		id := hook.GetID()
+		success, failure, err := countHookDeliveries(func(opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error) {
+			return c.client.Repositories.ListHookDeliveries(ctx, org, repo, id, opts)
+		})
+		if err != nil {
+			sendError(errCh, err)
+			return
+		}
+		c.collectHook(ch, hook, success, failure, org, repo)
+	}
+}
+
+func (c *webhooksCollector) collectHook(ch chan<- metrics.Metric, hook *github.Hook, success int, failure int, org string, repo string) {
+	id := strconv.FormatInt(hook.GetID(), 10)
+
+	ch <- metrics.MustNewConstMetric(
+		c.hookActive, metrics.GaugeValue, boolToFloat64(hook.GetActive()),
+		org, repo, id, hook.GetName(),
+	)
+	// Last response code is null until the webhook is delivered for the first time
+	if code, ok := hook.LastResponse["code"].(float64); ok {
+		status, _ := hook.LastResponse["status"].(string)
+		ch <- metrics.MustNewConstMetric(
+			c.lastResponseCode, metrics.GaugeValue, code,
+			org, repo, id, status,
+		)
+	}
+	ch <- metrics.MustNewConstMetric(
+		c.deliveriesCount, metrics.GaugeValue, float64(success),
+		org, repo, id, "success",
+	)
+	ch <- metrics.MustNewConstMetric(
+		c.deliveriesCount, metrics.GaugeValue, float64(failure),
+		org, repo, id, "failure",
+	)
+}
+
+// countHookDeliveries pages through webhook deliveries from the most recent one
+// until the first one delivered outside of the configured window, and returns
+// the count of successful (2xx) and failed deliveries
+func countHookDeliveries(list func(opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error)) (int, int, error) {
+	since := time.Now().Add(-viper.GetDuration("webhooks-deliveries-window"))
+	success := 0
+	failure := 0
+	opts := &github.ListCursorOptions{
+		PerPage: 100,
+	}
+	for {
+		results, resp, err := list(opts)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, delivery := range results {
+			if delivery.GetDeliveredAt().Before(since) {
+				return success, failure, nil
+			}
+			if code := delivery.GetStatusCode(); code >= 200 && code < 300 {
+				success++
+			} else {
+				failure++
+			}
+		}
+		if resp.Cursor == "" {
+			break
+		}
+		opts.Cursor = resp.Cursor
+	}
+	return success, failure, nil
+}
+
+func init() {
+	WebhooksFlagset.Duration("webhooks-deliveries-window", 24*time.Hour, "Time window of webhook deliveries to collect metrics from (WEBHOOKS_DELIVERIES_WINDOW)")
+
+	registerCollector("webhooks", false, newWebhooksCollector)
+}
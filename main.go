@@ -61,11 +61,20 @@ func main() {
 	c.Flags().String("web-version-path", "/version", "Path to HTTP version (WEB_VERSION_PATH)")
 	c.Flags().StringSlice("gh-organizations", []string{}, "List of Github organizations to scrape (GH_ORGANIZATIONS)")
 	c.Flags().StringSlice("gh-repositories", []string{}, "List of Github repositories to scrape (GH_REPOSITORIES)")
+	c.Flags().StringSlice("gh-enterprises", []string{}, "List of Github enterprises to scrape (GH_ENTERPRISES)")
+	c.Flags().String("gh-enterprise-token", "", "Github personal access token of an enterprise owner, required to scrape enterprises (GH_ENTERPRISE_TOKEN)")
 	c.Flags().String("gh-private-key", "", "Github App Private Key (required) (GH_PRIVATE_KEY)")
 	c.Flags().Int64("gh-app-id", 0, "Github App application ID (required) (GH_APP_ID)")
 	c.Flags().Int64("gh-ins-id", 0, "Github App instalation ID (required) (GH_INS_ID)")
 
 	c.Flags().AddFlagSet(collectors.ActionsFlagset)
+	c.Flags().AddFlagSet(collectors.PullsFlagset)
+	c.Flags().AddFlagSet(collectors.IssuesFlagset)
+	c.Flags().AddFlagSet(collectors.DeploymentsFlagset)
+	c.Flags().AddFlagSet(collectors.DoraFlagset)
+	c.Flags().AddFlagSet(collectors.CommitsFlagset)
+	c.Flags().AddFlagSet(collectors.BranchesFlagset)
+	c.Flags().AddFlagSet(collectors.WebhooksFlagset)
 
 	c.MarkFlagRequired("gh-private-key")
 	c.MarkFlagRequired("gh-app-id")
@@ -92,10 +101,46 @@ func main() {
 		// TODO: Make this configurable
 		v := validators.NewRegexpValidator(
 			map[*regexp.Regexp]time.Duration{
-				regexp.MustCompile(`\/repos$`):           10 * time.Minute,
-				regexp.MustCompile(`\/workflows$`):       10 * time.Minute,
-				regexp.MustCompile(`\/actions/runners$`): 1 * time.Minute,
-				regexp.MustCompile(`\/actions/runs$`):    5 * time.Minute,
+				regexp.MustCompile(`\/repos$`):                     10 * time.Minute,
+				regexp.MustCompile(`^\/repos/[^/]+/[^/]+$`):        10 * time.Minute,
+				regexp.MustCompile(`\/workflows$`):                 10 * time.Minute,
+				regexp.MustCompile(`\/actions/runners$`):           1 * time.Minute,
+				regexp.MustCompile(`\/actions/runs$`):              5 * time.Minute,
+				regexp.MustCompile(`\/runner-groups$`):             10 * time.Minute,
+				regexp.MustCompile(`\/runner-groups/\d+/runners$`): 1 * time.Minute,
+				regexp.MustCompile(`\/pulls$`):                     5 * time.Minute,
+				regexp.MustCompile(`\/reviews$`):                   10 * time.Minute,
+				regexp.MustCompile(`\/issues$`):                    5 * time.Minute,
+				regexp.MustCompile(`\/jobs$`):                      30 * time.Minute,
+				regexp.MustCompile(`\/settings/billing/[\w-]+$`):   1 * time.Hour,
+				regexp.MustCompile(`\/actions/cache/usage`):        10 * time.Minute,
+				regexp.MustCompile(`\/actions/artifacts$`):         10 * time.Minute,
+				regexp.MustCompile(`\/dependabot/alerts$`):         10 * time.Minute,
+				regexp.MustCompile(`\/code-scanning/alerts$`):      10 * time.Minute,
+				regexp.MustCompile(`\/code-scanning/analyses$`):    10 * time.Minute,
+				regexp.MustCompile(`\/secret-scanning/alerts$`):    1 * time.Minute,
+				regexp.MustCompile(`\/releases$`):                  10 * time.Minute,
+				regexp.MustCompile(`\/traffic/`):                   1 * time.Hour,
+				regexp.MustCompile(`\/protection`):                 10 * time.Minute,
+				regexp.MustCompile(`\/members$`):                   10 * time.Minute,
+				regexp.MustCompile(`\/teams$`):                     10 * time.Minute,
+				regexp.MustCompile(`\/outside_collaborators$`):     10 * time.Minute,
+				regexp.MustCompile(`\/invitations$`):               10 * time.Minute,
+				regexp.MustCompile(`\/deployments$`):               5 * time.Minute,
+				regexp.MustCompile(`\/deployments/\d+/statuses$`):  5 * time.Minute,
+				regexp.MustCompile(`\/environments$`):              10 * time.Minute,
+				regexp.MustCompile(`\/approvals$`):                 10 * time.Minute,
+				regexp.MustCompile(`\/compare/`):                   1 * time.Hour,
+				regexp.MustCompile(`\/status$`):                    1 * time.Minute,
+				regexp.MustCompile(`\/check-runs$`):                1 * time.Minute,
+				regexp.MustCompile(`\/commits$`):                   5 * time.Minute,
+				regexp.MustCompile(`\/stats/code_frequency$`):      1 * time.Hour,
+				regexp.MustCompile(`\/branches$`):                  10 * time.Minute,
+				regexp.MustCompile(`\/branches/[^/]+$`):            1 * time.Hour,
+				regexp.MustCompile(`\/git/commits/[0-9a-f]{40}$`):  24 * time.Hour,
+				regexp.MustCompile(`\/packages$`):                  10 * time.Minute,
+				regexp.MustCompile(`\/hooks$`):                     10 * time.Minute,
+				regexp.MustCompile(`\/deliveries$`):                1 * time.Minute,
 			},
 		)
 
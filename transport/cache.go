@@ -91,7 +91,9 @@ func (t *cacheTransport) RoundTrip(req *http.Request) (resp *http.Response, err
 		}
 	}
 
-	if cacheable {
+	// Accepted responses are sent while Github computes the result in the
+	// background, so they must not be served from cache later on
+	if cacheable && resp.StatusCode != http.StatusAccepted {
 		buf, err := httputil.DumpResponse(resp, true)
 		if err == nil {
 			t.Cache.Set(req.URL.String(), buf)
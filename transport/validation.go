@@ -2,6 +2,7 @@ package transport
 
 import (
 	"bytes"
+	"context"
 	"io/ioutil"
 	"net/http"
 	"time"
@@ -9,6 +10,17 @@ import (
 	"github.com/konradasb/github_exporter/validators"
 )
 
+type maxAgeKey struct{}
+
+// WithMaxAge returns a copy of the context, overriding the maximum age of
+// cached responses to requests sent with it
+//
+// It's used for requests sharing URLs with other, less time sensitive
+// requests, which can't be told apart by a Validator
+func WithMaxAge(ctx context.Context, maxAge time.Duration) context.Context {
+	return context.WithValue(ctx, maxAgeKey{}, maxAge)
+}
+
 type revalidationTransport struct {
 	Validator validators.Validator
 	Next      http.RoundTripper
@@ -50,7 +62,15 @@ func (t *revalidationTransport) RoundTrip(req *http.Request) (*http.Response, er
 	x := req.Header.Get("X-Cache-Age")
 	if x != "" {
 		age, err := time.ParseDuration(x + "s")
-		if err == nil && t.Validator.Valid(req.URL, age) {
+		valid := false
+		if err == nil {
+			if maxAge, ok := req.Context().Value(maxAgeKey{}).(time.Duration); ok {
+				valid = age <= maxAge
+			} else {
+				valid = t.Validator.Valid(req.URL, age)
+			}
+		}
+		if valid {
 			resp := &http.Response{
 				Request:          req,
 				TransferEncoding: req.TransferEncoding,